@@ -0,0 +1,252 @@
+package monero
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrAccessDenied matches the errors of calls the daemon refused because they
+// are not allowed over restricted RPC, or because the credentials were
+// wrong. Use errors.Is to check for it.
+var ErrAccessDenied = errors.New("access denied")
+
+// AccessDeniedError is returned by operator calls the daemon refused.
+type AccessDeniedError struct {
+	Method string
+	Reason string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("%s: access denied: %s", e.Method, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrAccessDenied) true.
+func (e *AccessDeniedError) Is(target error) bool {
+	return target == ErrAccessDenied
+}
+
+// accessDenied turns err into an AccessDeniedError if it shows method was
+// refused. A daemon in restricted RPC mode does not serve operator methods at
+// all, so "not found" answers count as refusals.
+func accessDenied(method string, err error) error {
+	e, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+	if status, ok := e.Data.(int); ok {
+		switch status {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return &AccessDeniedError{Method: method, Reason: http.StatusText(status)}
+		case http.StatusNotFound:
+			return &AccessDeniedError{Method: method, Reason: "not served, the daemon is probably in restricted RPC mode"}
+		}
+	}
+	if e.Code == E_NO_METHOD {
+		return &AccessDeniedError{Method: method, Reason: "method not found, the daemon is probably in restricted RPC mode"}
+	}
+	msg := strings.ToLower(e.Message)
+	if strings.Contains(msg, "denied") || strings.Contains(msg, "restricted") {
+		return &AccessDeniedError{Method: method, Reason: e.Message}
+	}
+	return err
+}
+
+// statusReply is a response whose status operator checks.
+type statusReply interface {
+	status() string
+}
+
+// operatorStatus is embedded in responses of operator methods to make them a
+// statusReply.
+type operatorStatus struct {
+	Status string `json:"status"`
+}
+
+func (s operatorStatus) status() string {
+	return s.Status
+}
+
+// operator calls one of the daemon's other RPC methods that are not available
+// over restricted RPC and checks the status of the response.
+func (c *DaemonClient) operator(method string, req interface{}, rep statusReply) error {
+	if rep == nil {
+		rep = &operatorStatus{}
+	}
+	if err := c.DaemonOther(method, req, rep); err != nil {
+		return accessDenied(method, err)
+	}
+	return accessDenied(method, statusError(method, rep.status()))
+}
+
+// SaveBC saves the blockchain to disk.
+func (c *DaemonClient) SaveBC() error {
+	return c.operator("save_bc", nil, nil)
+}
+
+// SetLogLevel sets the daemon's log level, 0 to 4.
+func (c *DaemonClient) SetLogLevel(level int) error {
+	request := struct {
+		Level int `json:"level"`
+	}{level}
+	return c.operator("set_log_level", &request, nil)
+}
+
+// SetLogCategories sets the daemon's log categories, e.g. "*:WARNING,net:INFO",
+// and returns the categories now in effect.
+func (c *DaemonClient) SetLogCategories(categories string) (string, error) {
+	var response struct {
+		Categories string `json:"categories"`
+		operatorStatus
+	}
+	request := struct {
+		Categories string `json:"categories"`
+	}{categories}
+	if err := c.operator("set_log_categories", &request, &response); err != nil {
+		return "", err
+	}
+	return response.Categories, nil
+}
+
+// SetLogHashRate shows or hides the hash rate in the daemon's log. It fails if
+// the daemon is not mining.
+func (c *DaemonClient) SetLogHashRate(visible bool) error {
+	request := struct {
+		Visible bool `json:"visible"`
+	}{visible}
+	return c.operator("set_log_hash_rate", &request, nil)
+}
+
+// PopBlocks removes the top n blocks from the chain and returns the new
+// height.
+func (c *DaemonClient) PopBlocks(n uint64) (uint64, error) {
+	var response struct {
+		Height uint64 `json:"height"`
+		operatorStatus
+	}
+	request := struct {
+		NBlocks uint64 `json:"nblocks"`
+	}{n}
+	if err := c.operator("pop_blocks", &request, &response); err != nil {
+		return 0, err
+	}
+	return response.Height, nil
+}
+
+// PruneInfo
+// pruned - boolean; States if the blockchain is pruned.
+// pruning_seed - unsigned int; The pruning seed of the blockchain, 0 if it is not pruned.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type PruneInfo struct {
+	Pruned      bool   `json:"pruned"`
+	PruningSeed uint32 `json:"pruning_seed"`
+	Status      string `json:"status"`
+	Untrusted   bool   `json:"untrusted"`
+}
+
+// PruneBlockchain prunes the blockchain. With check it only reports whether
+// the blockchain is pruned.
+func (c *DaemonClient) PruneBlockchain(check bool) (PruneInfo, error) {
+	var pi PruneInfo
+	request := struct {
+		Check bool `json:"check"`
+	}{check}
+	if err := c.Daemon("prune_blockchain", &request, &pi); err != nil {
+		return pi, accessDenied("prune_blockchain", err)
+	}
+	return pi, accessDenied("prune_blockchain", statusError("prune_blockchain", pi.Status))
+}
+
+// StopDaemon shuts the daemon down.
+func (c *DaemonClient) StopDaemon() error {
+	return c.operator("stop_daemon", nil, nil)
+}
+
+// Commands for DaemonClient.Update.
+const (
+	UpdateCheck    = "check"
+	UpdateDownload = "download"
+)
+
+// UpdateInfo
+// update - boolean; States if an update is available.
+// version - string; Version available for download.
+// user_uri - string; Download URI for users.
+// auto_uri - string; Download URI for automatic updates.
+// hash - string; Hash of the download.
+// path - string; Path the update was downloaded to.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type UpdateInfo struct {
+	Update    bool   `json:"update"`
+	Version   string `json:"version"`
+	UserURI   string `json:"user_uri"`
+	AutoURI   string `json:"auto_uri"`
+	Hash      string `json:"hash"`
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Untrusted bool   `json:"untrusted"`
+}
+
+func (u UpdateInfo) status() string {
+	return u.Status
+}
+
+// Update checks for a new daemon version with UpdateCheck or downloads it to
+// path with UpdateDownload. An empty path downloads to the default location.
+func (c *DaemonClient) Update(command, path string) (UpdateInfo, error) {
+	var ui UpdateInfo
+	request := struct {
+		Command string `json:"command"`
+		Path    string `json:"path,omitempty"`
+	}{command, path}
+	if err := c.operator("update", &request, &ui); err != nil {
+		return ui, err
+	}
+	return ui, nil
+}
+
+// NetStats
+// start_time - unsigned int; Unix time the daemon started.
+// total_packets_in - unsigned int; Number of packets received.
+// total_bytes_in - unsigned int; Number of bytes received.
+// total_packets_out - unsigned int; Number of packets sent.
+// total_bytes_out - unsigned int; Number of bytes sent.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type NetStats struct {
+	StartTime       uint64 `json:"start_time"`
+	TotalPacketsIn  uint64 `json:"total_packets_in"`
+	TotalBytesIn    uint64 `json:"total_bytes_in"`
+	TotalPacketsOut uint64 `json:"total_packets_out"`
+	TotalBytesOut   uint64 `json:"total_bytes_out"`
+	Status          string `json:"status"`
+	Untrusted       bool   `json:"untrusted"`
+}
+
+func (n NetStats) status() string {
+	return n.Status
+}
+
+// GetNetStats returns the daemon's network traffic statistics.
+func (c *DaemonClient) GetNetStats() (NetStats, error) {
+	var ns NetStats
+	if err := c.operator("get_net_stats", nil, &ns); err != nil {
+		return ns, err
+	}
+	return ns, nil
+}
+
+// GetLimit returns the daemon's bandwidth limits in kB per second.
+func (c *DaemonClient) GetLimit() (BandwidthLimit, error) {
+	var response struct {
+		BandwidthLimit
+		operatorStatus
+	}
+	if err := c.operator("get_limit", nil, &response); err != nil {
+		return response.BandwidthLimit, err
+	}
+	return response.BandwidthLimit, nil
+}
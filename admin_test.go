@@ -0,0 +1,59 @@
+package monero
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccessDenied(t *testing.T) {
+	// restricted answers like a daemon started with --restricted-rpc, or one
+	// behind a proxy that forbids some paths.
+	restricted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json_rpc":
+			fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"}}`)
+		case "/stop_daemon":
+			w.WriteHeader(http.StatusForbidden)
+		case "/get_net_stats":
+			fmt.Fprint(w, `{"status":"Restricted RPC","untrusted":false}`)
+		case "/set_log_hash_rate":
+			fmt.Fprint(w, `{"status":"NOT MINING","untrusted":false}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer restricted.Close()
+	c := NewDaemonClient(restricted.URL + "/json_rpc")
+
+	denied := map[string]error{"save_bc": c.SaveBC(), "stop_daemon": c.StopDaemon()}
+	_, denied["prune_blockchain"] = c.PruneBlockchain(true)
+	_, denied["get_net_stats"] = c.GetNetStats()
+	_, denied["pop_blocks"] = c.PopBlocks(1)
+	for method, err := range denied {
+		var ade *AccessDeniedError
+		if !errors.Is(err, ErrAccessDenied) || !errors.As(err, &ade) || ade.Method != method {
+			t.Errorf("%s: got %v, want access denied", method, err)
+		}
+	}
+
+	err := c.SetLogHashRate(true)
+	if err == nil || errors.Is(err, ErrAccessDenied) {
+		t.Errorf("set_log_hash_rate: got %v, want a status error", err)
+	}
+}
+
+func TestPruneBlockchainStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","result":{"pruned":false,"pruning_seed":0,"status":"Failed to prune blockchain"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	_, err := c.PruneBlockchain(false)
+	if err == nil || errors.Is(err, ErrAccessDenied) {
+		t.Errorf("got %v, want a status error", err)
+	}
+}
@@ -0,0 +1,320 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// MaxReserveSize is the largest extra nonce space a block template can
+// reserve.
+const MaxReserveSize = 255
+
+// BlockTemplateRequest
+// wallet_address - string; Address the block reward goes to.
+// reserve_size - unsigned int; Bytes to reserve in the template for an extra nonce, at most MaxReserveSize.
+// extra_nonce - string; Extra nonce to put into the template as hex, instead of reserving space.
+// prev_block - string; Hash of the block to build on instead of the top of the chain.
+type BlockTemplateRequest struct {
+	WalletAddress string `json:"wallet_address"`
+	ReserveSize   uint   `json:"reserve_size"`
+	ExtraNonce    string `json:"extra_nonce,omitempty"`
+	PrevBlock     string `json:"prev_block,omitempty"`
+}
+
+// GetBlockTemplateWith gets a block template like GetBlockTemplate, with an
+// optional extra nonce and parent block.
+func (c *DaemonClient) GetBlockTemplateWith(req BlockTemplateRequest) (BlockTemplate, error) {
+	var bt BlockTemplate
+	if req.ReserveSize > MaxReserveSize {
+		return bt, fmt.Errorf("reserve size %d exceeds %d", req.ReserveSize, MaxReserveSize)
+	}
+	if req.ReserveSize > 0 && req.ExtraNonce != "" {
+		return bt, errors.New("reserve size and extra nonce are mutually exclusive")
+	}
+	if err := c.Daemon("getblocktemplate", req, &bt); err != nil {
+		return bt, err
+	}
+	return bt, nil
+}
+
+// ErrNoReservedSpace is returned by BlockTemplate.SetExtraNonce for templates
+// without reserved space.
+var ErrNoReservedSpace = errors.New("block template has no reserved space")
+
+// ExtraNonceSize returns the size of the space reserved for an extra nonce,
+// the reserve size the template was requested with.
+func (bt *BlockTemplate) ExtraNonceSize() (int, error) {
+	blob, err := hex.DecodeString(bt.BlockTemplateBlob)
+	if err != nil {
+		return 0, err
+	}
+	l, err := parseTemplate(blob)
+	if err != nil {
+		return 0, err
+	}
+	return l.reservedSize(blob, int(bt.ReservedOffset))
+}
+
+// SetExtraNonce writes nonce into the reserved space of the template, padded
+// with zeros, and updates BlockHashingBlob.
+func (bt *BlockTemplate) SetExtraNonce(nonce []byte) error {
+	blob, err := hex.DecodeString(bt.BlockTemplateBlob)
+	if err != nil {
+		return err
+	}
+	l, err := parseTemplate(blob)
+	if err != nil {
+		return err
+	}
+	size, err := l.reservedSize(blob, int(bt.ReservedOffset))
+	if err != nil {
+		return err
+	}
+	if len(nonce) > size {
+		return fmt.Errorf("extra nonce of %d bytes exceeds the %d reserved", len(nonce), size)
+	}
+	reserved := blob[bt.ReservedOffset : int(bt.ReservedOffset)+size]
+	for i := range reserved {
+		reserved[i] = 0
+	}
+	copy(reserved, nonce)
+	bt.update(blob, l)
+	return nil
+}
+
+// SetNonce sets the miner nonce in the template header and updates
+// BlockHashingBlob.
+func (bt *BlockTemplate) SetNonce(nonce uint32) error {
+	blob, err := hex.DecodeString(bt.BlockTemplateBlob)
+	if err != nil {
+		return err
+	}
+	l, err := parseTemplate(blob)
+	if err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(blob[l.nonce:], nonce)
+	bt.update(blob, l)
+	return nil
+}
+
+// HashingBlob computes the blob the proof of work is computed on from the
+// template: the block header, the root of the transaction tree and the number
+// of transactions.
+func (bt *BlockTemplate) HashingBlob() (string, error) {
+	blob, err := hex.DecodeString(bt.BlockTemplateBlob)
+	if err != nil {
+		return "", err
+	}
+	l, err := parseTemplate(blob)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(l.hashingBlob(blob)), nil
+}
+
+func (bt *BlockTemplate) update(blob []byte, l templateLayout) {
+	bt.BlockTemplateBlob = hex.EncodeToString(blob)
+	bt.BlockHashingBlob = hex.EncodeToString(l.hashingBlob(blob))
+}
+
+// TreeHash returns the root of Monero's merkle tree of hashes, used for the
+// transactions of a block and the chains of a merge mining tag.
+func TreeHash(hashes [][32]byte) [32]byte {
+	switch len(hashes) {
+	case 0:
+		return [32]byte{}
+	case 1:
+		return hashes[0]
+	case 2:
+		return keccak256(hashes[0][:], hashes[1][:])
+	}
+	cnt := 2
+	for cnt < len(hashes) {
+		cnt <<= 1
+	}
+	cnt >>= 1
+	ints := make([][32]byte, cnt)
+	i := 2*cnt - len(hashes)
+	copy(ints, hashes[:i])
+	for j := i; j < cnt; i, j = i+2, j+1 {
+		ints[j] = keccak256(hashes[i][:], hashes[i+1][:])
+	}
+	for cnt > 2 {
+		cnt >>= 1
+		for j := 0; j < cnt; j++ {
+			ints[j] = keccak256(ints[2*j][:], ints[2*j+1][:])
+		}
+	}
+	return keccak256(ints[0][:], ints[1][:])
+}
+
+// templateLayout locates the parts of a block template blob needed to mine
+// it.
+type templateLayout struct {
+	headerEnd  int
+	nonce      int
+	extraStart int
+	extraEnd   int
+	prefixEnd  int
+	minerTxEnd int
+	version    uint64
+	txHashes   [][32]byte
+}
+
+// parseTemplate parses the header and miner transaction of a block blob.
+func parseTemplate(blob []byte) (templateLayout, error) {
+	var l templateLayout
+	r := &blobReader{b: blob}
+	for i := 0; i < 3; i++ { // major and minor version, timestamp
+		if _, err := r.varint(); err != nil {
+			return l, err
+		}
+	}
+	if _, err := r.bytes(32); err != nil {
+		return l, err
+	}
+	l.nonce = r.pos
+	if _, err := r.bytes(4); err != nil {
+		return l, err
+	}
+	l.headerEnd = r.pos
+
+	var err error
+	if l.version, err = r.varint(); err != nil {
+		return l, err
+	}
+	if _, err := r.varint(); err != nil { // unlock time
+		return l, err
+	}
+	inputs, err := r.varint()
+	if err != nil {
+		return l, err
+	}
+	for i := uint64(0); i < inputs; i++ {
+		tag, err := r.bytes(1)
+		if err != nil {
+			return l, err
+		}
+		if tag[0] != 0xff {
+			return l, fmt.Errorf("miner transaction has a non-coinbase input")
+		}
+		if _, err := r.varint(); err != nil { // height
+			return l, err
+		}
+	}
+	outputs, err := r.varint()
+	if err != nil {
+		return l, err
+	}
+	for i := uint64(0); i < outputs; i++ {
+		if _, err := r.varint(); err != nil { // amount
+			return l, err
+		}
+		tag, err := r.bytes(1)
+		if err != nil {
+			return l, err
+		}
+		n := 32
+		switch tag[0] {
+		case 0x02:
+		case 0x03:
+			n++ // view tag
+		default:
+			return l, fmt.Errorf("unknown output type %#x", tag[0])
+		}
+		if _, err := r.bytes(n); err != nil {
+			return l, err
+		}
+	}
+	extra, err := r.varint()
+	if err != nil {
+		return l, err
+	}
+	l.extraStart = r.pos
+	if extra > uint64(len(blob)) {
+		return l, errShortBlob
+	}
+	if _, err := r.bytes(int(extra)); err != nil {
+		return l, err
+	}
+	l.extraEnd = r.pos
+	l.prefixEnd = r.pos
+	if l.version >= 2 {
+		rctType, err := r.bytes(1)
+		if err != nil {
+			return l, err
+		}
+		if rctType[0] != RCTTypeNull {
+			return l, fmt.Errorf("miner transaction has RingCT type %d", rctType[0])
+		}
+	}
+	l.minerTxEnd = r.pos
+
+	count, err := r.varint()
+	if err != nil {
+		return l, err
+	}
+	if count > uint64(len(blob)) {
+		return l, errShortBlob
+	}
+	l.txHashes = make([][32]byte, count)
+	for i := range l.txHashes {
+		h, err := r.bytes(32)
+		if err != nil {
+			return l, err
+		}
+		copy(l.txHashes[i][:], h)
+	}
+	if r.pos != len(blob) {
+		return l, fmt.Errorf("%d trailing bytes after the block", len(blob)-r.pos)
+	}
+	return l, nil
+}
+
+// minerTxID returns the id of the miner transaction. Since version 2 it is the
+// hash of the prefix hash, the hash of the RingCT base and a zero hash for the
+// empty prunable part.
+func (l templateLayout) minerTxID(blob []byte) [32]byte {
+	tx := blob[l.headerEnd:l.minerTxEnd]
+	if l.version < 2 {
+		return keccak256(tx)
+	}
+	prefix := keccak256(blob[l.headerEnd:l.prefixEnd])
+	base := keccak256(blob[l.prefixEnd:l.minerTxEnd])
+	return keccak256(prefix[:], base[:], make([]byte, 32))
+}
+
+func (l templateLayout) hashingBlob(blob []byte) []byte {
+	hashes := append([][32]byte{l.minerTxID(blob)}, l.txHashes...)
+	root := TreeHash(hashes)
+	hb := make([]byte, 0, l.headerEnd+32+binary.MaxVarintLen64)
+	hb = append(hb, blob[:l.headerEnd]...)
+	hb = append(hb, root[:]...)
+	var count [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(count[:], uint64(len(hashes)))
+	return append(hb, count[:n]...)
+}
+
+// reservedSize returns the size of the reserved space at offset, which must be
+// the data of an extra nonce field in the miner transaction's extra.
+func (l templateLayout) reservedSize(blob []byte, offset int) (int, error) {
+	if offset == 0 {
+		return 0, ErrNoReservedSpace
+	}
+	if offset <= l.extraStart || offset > l.extraEnd {
+		return 0, fmt.Errorf("reserved offset %d is outside the miner transaction extra", offset)
+	}
+	// monerod writes the size as a single byte between the nonce tag and
+	// the data, not as a varint.
+	if offset-2 >= l.extraStart && blob[offset-2] == TxExtraTagNonce {
+		size := int(blob[offset-1])
+		if offset+size > l.extraEnd {
+			return 0, fmt.Errorf("reserved space of %d bytes exceeds the miner transaction extra", size)
+		}
+		return size, nil
+	}
+	return 0, fmt.Errorf("no extra nonce at reserved offset %d", offset)
+}
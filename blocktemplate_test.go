@@ -0,0 +1,185 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const genesisTx = "013c01ff0001ffffffffffff03029b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd08807121017767aafcde9be00dcfd098715ebcf7f410daebc582fda69d24a28e9d0bc890d1"
+
+func TestKeccak(t *testing.T) {
+	for _, tc := range []struct {
+		data string
+		hash string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{strings.Repeat("a", 135), "34367dc248bbd832f4e3e69dfaac2f92638bd0bbd18f2912ba4ef454919cf446"},
+		{strings.Repeat("a", 136), "a6c4d403279fe3e0af03729caada8374b5ca54d8065329a3ebcaeb4b60aa386e"},
+	} {
+		sum := keccak256([]byte(tc.data))
+		if got := hex.EncodeToString(sum[:]); got != tc.hash {
+			t.Errorf("%d bytes: got %s, want %s", len(tc.data), got, tc.hash)
+		}
+	}
+}
+
+func TestTreeHash(t *testing.T) {
+	roots := []string{
+		"bc36789e7a1e281436464229828f817d6612f7b477d66591ff96a9e064bcc98a",
+		"57d772147cdf27f5f67d679f0f3a513f8b87622ce598a3cf0b048ab178ddfc6e",
+		"31ea648480acca9d46c5cfd2fd5ecf576ce7a797bdd582869c38deeacf6d17d4",
+		"dd5115b5dcca3db0bffa31064a0d21f21362cd02e1263e47d69e38bbeec1d359",
+		"3b85b9b4e7171846e3dd41d242f99cdc136467ff276a272d5d8f960b2c447d67",
+		"339caf14b48992a6c4f2f7fcdb491952fb108febcab38667df0828be8f3651a7",
+	}
+	var hashes [][32]byte
+	for i, want := range roots {
+		hashes = append(hashes, keccak256([]byte{byte(i)}))
+		root := TreeHash(hashes)
+		if got := hex.EncodeToString(root[:]); got != want {
+			t.Errorf("%d hashes: got %s, want %s", len(hashes), got, want)
+		}
+	}
+}
+
+func loadBlockTemplate(t *testing.T) BlockTemplate {
+	data, err := ioutil.ReadFile("testdata/fixtures/daemon/getblocktemplate.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Result BlockTemplate `json:"result"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	return fixture.Result
+}
+
+func TestBlockTemplate(t *testing.T) {
+	bt := loadBlockTemplate(t)
+	hb, err := bt.HashingBlob()
+	if err != nil || hb != bt.BlockHashingBlob {
+		t.Fatalf("got hashing blob %s, %v, want %s", hb, err, bt.BlockHashingBlob)
+	}
+	if size, err := bt.ExtraNonceSize(); err != nil || size != 60 {
+		t.Errorf("got extra nonce size %d, %v", size, err)
+	}
+
+	if err := bt.SetExtraNonce([]byte{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4000000000ff83b6d5e426eb3d3a681b9b222ca0e7ed432a9ba272ba4bbdb3461ebe23b0902"; bt.BlockHashingBlob != want {
+		t.Errorf("got hashing blob %s after the extra nonce, want %s", bt.BlockHashingBlob, want)
+	}
+	if err := bt.SetNonce(0xdeadbeef); err != nil {
+		t.Fatal(err)
+	}
+	want := "0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4efbeadde0ff83b6d5e426eb3d3a681b9b222ca0e7ed432a9ba272ba4bbdb3461ebe23b0902"
+	if bt.BlockHashingBlob != want {
+		t.Errorf("got hashing blob %s after the nonce, want %s", bt.BlockHashingBlob, want)
+	}
+	if !strings.Contains(bt.BlockTemplateBlob, "023c01020304000000") {
+		t.Errorf("extra nonce missing from %s", bt.BlockTemplateBlob)
+	}
+
+	// A shorter extra nonce overwrites the whole reserved space.
+	if err := bt.SetExtraNonce(nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := bt.SetNonce(0); err != nil {
+		t.Fatal(err)
+	}
+	if fresh := loadBlockTemplate(t); bt != fresh {
+		t.Errorf("got %+v after clearing the nonces, want %+v", bt, fresh)
+	}
+
+	if err := bt.SetExtraNonce(make([]byte, 61)); err == nil {
+		t.Error("expected an error for an extra nonce larger than the reserved space")
+	}
+	bt.ReservedOffset = 0
+	if err := bt.SetExtraNonce([]byte{1}); !errors.Is(err, ErrNoReservedSpace) {
+		t.Errorf("got %v, want ErrNoReservedSpace", err)
+	}
+	bt.ReservedOffset = 100
+	if err := bt.SetExtraNonce([]byte{1}); err == nil {
+		t.Error("expected an error for a reserved offset outside the extra nonce")
+	}
+	bt.BlockTemplateBlob = bt.BlockTemplateBlob[:200]
+	if _, err := bt.HashingBlob(); err == nil {
+		t.Error("expected an error for a truncated blob")
+	}
+}
+
+// TestLargeReserveSize checks reserve sizes of 128 and more, whose size byte
+// monerod writes as a raw byte rather than a varint.
+func TestLargeReserveSize(t *testing.T) {
+	bt := loadBlockTemplate(t)
+	blob, _ := hex.DecodeString(bt.BlockTemplateBlob)
+	l, err := parseTemplate(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rebuild the extra as monerod does for reserve_size 200: the tx public
+	// key followed by a nonce tag, the size byte and the reserved space.
+	pubKey := blob[l.extraStart : l.extraStart+33]
+	extra := append(append([]byte{}, pubKey...), TxExtraTagNonce, 200)
+	extra = append(extra, make([]byte, 200)...)
+	var size [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(size[:], uint64(len(extra)))
+	large := append(append([]byte{}, blob[:l.extraStart-1]...), size[:n]...)
+	offset := len(large) + 35
+	large = append(large, extra...)
+	large = append(large, blob[l.extraEnd:]...)
+	bt.BlockTemplateBlob = hex.EncodeToString(large)
+	bt.ReservedOffset = uint(offset)
+
+	if size, err := bt.ExtraNonceSize(); err != nil || size != 200 {
+		t.Fatalf("got extra nonce size %d, %v, want 200", size, err)
+	}
+	nonce := make([]byte, 200)
+	nonce[0], nonce[199] = 1, 2
+	if err := bt.SetExtraNonce(nonce); err != nil {
+		t.Fatal(err)
+	}
+	if got := bt.BlockTemplateBlob[2*offset-4 : 2*offset+4]; got != "02c80100" {
+		t.Errorf("got %s around the reserved offset", got)
+	}
+	if err := bt.SetExtraNonce(make([]byte, 201)); err == nil {
+		t.Error("expected an error for an extra nonce larger than the reserved space")
+	}
+}
+
+func TestGenesisHashingBlob(t *testing.T) {
+	var header [39]byte
+	header[0] = 1 // major version, minor version and timestamp are 0
+	binary.LittleEndian.PutUint32(header[35:], 10000)
+	bt := BlockTemplate{BlockTemplateBlob: hex.EncodeToString(header[:]) + genesisTx + "00"}
+	hb, err := bt.HashingBlob()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, _ := hex.DecodeString(hb)
+	id := keccak256([]byte{byte(len(blob))}, blob)
+	if got := hex.EncodeToString(id[:]); got != "418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3" {
+		t.Errorf("got genesis block id %s", got)
+	}
+}
+
+func TestGetBlockTemplateWith(t *testing.T) {
+	c := NewDaemonClient("http://127.0.0.1:0/json_rpc")
+	for _, req := range []BlockTemplateRequest{
+		{WalletAddress: miningAddress, ReserveSize: 256},
+		{WalletAddress: miningAddress, ReserveSize: 8, ExtraNonce: "0102"},
+	} {
+		if _, err := c.GetBlockTemplateWith(req); err == nil || strings.Contains(err.Error(), "connect") {
+			t.Errorf("%+v: got %v, want a validation error", req, err)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"log"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -45,14 +46,43 @@ type CallClient struct {
 	endpoint string
 	username string
 	password string
+
+	// client is used for all requests, a new default client is used
+	// when it is nil.
+	client *http.Client
+
+	// onSchemaDrift, when set, is called whenever a response does not
+	// match the struct it is decoded into.
+	onSchemaDrift SchemaDriftHandler
 }
 
 func NewCallClient(endpoint, username, password string) *CallClient {
-	return &CallClient{endpoint, username, password}
+	return &CallClient{endpoint: endpoint, username: username, password: password}
+}
+
+// SetHTTPClient sets the http client used for all requests, e.g. one with
+// timeouts or a custom transport such as a ChaosTransport.
+func (c *CallClient) SetHTTPClient(client *http.Client) {
+	c.client = client
+}
+
+func (c *CallClient) httpClient() *http.Client {
+	if c.client != nil {
+		return c.client
+	}
+	return &http.Client{}
+}
+
+// OnSchemaDrift enables schema drift detection. Every response is compared
+// against the struct it is decoded into and h is called with the fields that
+// the struct does not know about and the fields that were expected but never
+// arrived. Passing nil disables detection again.
+func (c *CallClient) OnSchemaDrift(h SchemaDriftHandler) {
+	c.onSchemaDrift = h
 }
 
 func (c *CallClient) Daemon(method string, req, rep interface{}) error {
-	client := &http.Client{}
+	client := c.httpClient()
 	reqest, _ := http.NewRequest("POST", c.endpoint, EncodeClientRequest(method, req))
 	reqest.Header.Set("Content-Type", "application/json")
 	resp, err := client.Do(reqest)
@@ -60,19 +90,74 @@ func (c *CallClient) Daemon(method string, req, rep interface{}) error {
 		return err
 	}
 	defer resp.Body.Close()
-	return DecodeClientResponse(resp.Body, rep)
+	return c.decodeResponse(method, resp.Body, rep)
+}
+
+// DaemonOther calls one of the daemon's "other" RPC methods. These are not
+// JSON-RPC methods but separate endpoints next to /json_rpc, e.g. /get_height,
+// that take and return plain JSON objects.
+func (c *CallClient) DaemonOther(method string, req, rep interface{}) error {
+	var body io.Reader = http.NoBody
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	client := c.httpClient()
+	reqest, err := http.NewRequest("POST", c.otherEndpoint(method), body)
+	if err != nil {
+		return err
+	}
+	reqest.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(reqest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &Error{
+			Code:    E_SERVER,
+			Message: fmt.Sprintf("%s: %s", method, resp.Status),
+			Data:    resp.StatusCode,
+		}
+	}
+	if rep == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, rep); err != nil {
+		return err
+	}
+	c.checkSchema(method, data, rep)
+	return nil
+}
+
+// otherEndpoint returns the URL of the "other" RPC method next to the
+// JSON-RPC endpoint the client was created with.
+func (c *CallClient) otherEndpoint(method string) string {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return c.endpoint
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "json_rpc"), "/") + "/" + method
+	return u.String()
 }
 
 func (c *CallClient) Wallet(method string, req, rep interface{}) error {
-	client := &http.Client{}
+	client := c.httpClient()
 	reqest, _ := http.NewRequest("POST", c.endpoint, EncodeClientRequest(method, req))
 	resp, err := client.Do(reqest)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	io.Copy(ioutil.Discard, resp.Body)
 	if resp.StatusCode == http.StatusUnauthorized {
+		io.Copy(ioutil.Discard, resp.Body)
 		var authorization map[string]string = DigestAuthParams(resp)
 		// log.Println("authorization", authorization)
 		realmHeader := authorization["realm"]
@@ -118,9 +203,37 @@ func (c *CallClient) Wallet(method string, req, rep interface{}) error {
 		// 	log.Println("read body error:", err)
 		// }
 		// log.Println("read body data2222:", string(data), resp.StatusCode)
-		return DecodeClientResponse(resp.Body, rep)
+		return c.decodeResponse(method, resp.Body, rep)
+	}
+	return c.decodeResponse(method, resp.Body, rep)
+}
+
+// decodeResponse decodes a response like DecodeClientResponse and reports
+// schema drift for method if detection is enabled.
+func (c *CallClient) decodeResponse(method string, r io.Reader, reply interface{}) error {
+	result, err := decodeClientResult(r)
+	if err != nil {
+		return err
+	}
+	if reply == nil {
+		return nil
+	}
+	if err := json.Unmarshal(*result, reply); err != nil {
+		return err
+	}
+	c.checkSchema(method, *result, reply)
+	return nil
+}
+
+// checkSchema reports schema drift between data and reply if detection is
+// enabled.
+func (c *CallClient) checkSchema(method string, data []byte, reply interface{}) {
+	if c.onSchemaDrift == nil {
+		return
+	}
+	if drift := DetectSchemaDrift(method, data, reply); drift.Detected() {
+		c.onSchemaDrift(drift)
 	}
-	return DecodeClientResponse(resp.Body, rep)
 }
 
 // EncodeClientRequest encodes parameters for a JSON-RPC client request.
@@ -139,28 +252,41 @@ func EncodeClientRequest(method string, args interface{}) *bytes.Reader {
 // DecodeClientResponse decodes the response body of a client request into
 // the interface reply.
 func DecodeClientResponse(r io.Reader, reply interface{}) error {
+	result, err := decodeClientResult(r)
+	if err != nil {
+		return err
+	}
+	if reply == nil {
+		return nil
+	}
+	return json.Unmarshal(*result, reply)
+}
+
+// decodeClientResult decodes the response body of a client request and
+// returns the raw result, or the error returned by the server.
+func decodeClientResult(r io.Reader) (*json.RawMessage, error) {
 	var c clientResponse
 	if err := json.NewDecoder(r).Decode(&c); err != nil {
 		log.Println("read Decode Error:", c)
-		return err
+		return nil, err
 	}
 	// log.Println("read body Result:", string(*c.Result))
 	if c.Error != nil {
 		jsonErr := &Error{}
 		if err := json.Unmarshal(*c.Error, jsonErr); err != nil {
 			log.Println("read Error Error:", string(*c.Error))
-			return &Error{
+			return nil, &Error{
 				Code:    E_SERVER,
 				Message: string(*c.Error),
 			}
 		}
 		log.Println("read body Error:", string(*c.Error))
-		return jsonErr
+		return nil, jsonErr
 	}
 
 	if c.Result == nil {
-		return ErrNullResult
+		return nil, ErrNullResult
 	}
 	// log.Println("read body Result:", string(*c.Result))
-	return json.Unmarshal(*c.Result, reply)
+	return c.Result, nil
 }
@@ -0,0 +1,81 @@
+package monero
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeClientResponseError(t *testing.T) {
+	body := `{"id":"0","jsonrpc":"2.0","error":{"code":-13,"message":"No wallet file"}}`
+	var rep Balance
+	err := DecodeClientResponse(strings.NewReader(body), &rep)
+	rpcErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("got error %#v, want *Error", err)
+	}
+	if rpcErr.Code != -13 || rpcErr.Message != "No wallet file" {
+		t.Errorf("got %+v", rpcErr)
+	}
+}
+
+func TestDecodeClientResponseNullResult(t *testing.T) {
+	body := `{"id":"0","jsonrpc":"2.0"}`
+	var rep Balance
+	if err := DecodeClientResponse(strings.NewReader(body), &rep); err != ErrNullResult {
+		t.Errorf("got error %v, want %v", err, ErrNullResult)
+	}
+}
+
+func TestOtherEndpoint(t *testing.T) {
+	for endpoint, want := range map[string]string{
+		"http://127.0.0.1:18081/json_rpc":   "http://127.0.0.1:18081/get_height",
+		"http://127.0.0.1:18081":            "http://127.0.0.1:18081/get_height",
+		"https://node.example/xmr/json_rpc": "https://node.example/xmr/get_height",
+	} {
+		c := NewCallClient(endpoint, "", "")
+		if got := c.otherEndpoint("get_height"); got != want {
+			t.Errorf("%s: got %s, want %s", endpoint, got, want)
+		}
+	}
+}
+
+func FuzzDecodeClientResponse(f *testing.F) {
+	fixtures, _ := filepath.Glob(filepath.Join("testdata", "fixtures", "*", "*.json"))
+	for _, name := range fixtures {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte(`{"id":"0","jsonrpc":"2.0","error":{"code":-13,"message":"No wallet file"}}`))
+	f.Add([]byte(`{"id":"0","jsonrpc":"2.0","error":"not an object"}`))
+	f.Add([]byte(`{"id":"0","jsonrpc":"2.0","result":null}`))
+	f.Add([]byte(`{"id":"0","jsonrpc":"2.0","result":{"block_header":{"reward":-1}}}`))
+	f.Add([]byte(`{"result":`))
+
+	out := log.Writer()
+	log.SetOutput(ioutil.Discard)
+	f.Cleanup(func() { log.SetOutput(out) })
+	f.Fuzz(func(t *testing.T, data []byte) {
+		replies := []interface{}{
+			new(interface{}),
+			new(Block),
+			new(BlockHeaderResponse),
+			new(Info),
+			new(Transfers),
+		}
+		for _, reply := range replies {
+			if err := DecodeClientResponse(bytes.NewReader(data), reply); err != nil {
+				continue
+			}
+			if result, err := decodeClientResult(bytes.NewReader(data)); err == nil {
+				DetectSchemaDrift("fuzz", *result, reply)
+			}
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package monero
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AnyMethod can be used with ChaosTransport.SetFault to inject a fault into
+// every method that has no fault of its own.
+const AnyMethod = "*"
+
+// FaultKind selects the failure a ChaosTransport injects.
+type FaultKind int
+
+const (
+	// FaultNone forwards the request untouched, apart from any latency.
+	FaultNone FaultKind = iota
+
+	// FaultDropConnection forwards the request but fails reading the
+	// response body with io.ErrUnexpectedEOF after Fault.Bytes bytes, like a
+	// connection dropped mid-body.
+	FaultDropConnection
+
+	// FaultHTTPStatus responds with Fault.StatusCode without forwarding the
+	// request, e.g. 500 or 502 from a proxy in front of the node.
+	FaultHTTPStatus
+
+	// FaultTruncated forwards the request and cuts the response body after
+	// Fault.Bytes bytes, or in half if Bytes is zero or not shorter than the
+	// body.
+	FaultTruncated
+
+	// FaultMalformed responds with a body that is not valid JSON without
+	// forwarding the request.
+	FaultMalformed
+
+	// FaultRPCError responds with a JSON-RPC error carrying Fault.Code and
+	// Fault.Message without forwarding the request.
+	FaultRPCError
+)
+
+// Fault describes a failure injected into calls of a single RPC method.
+type Fault struct {
+	Kind FaultKind
+
+	// Latency is added before the request is handled.
+	Latency time.Duration
+
+	// StatusCode is the response status for FaultHTTPStatus and must be
+	// set for it.
+	StatusCode int
+
+	// Bytes is the number of body bytes delivered for FaultDropConnection
+	// and FaultTruncated.
+	Bytes int
+
+	// Code and Message make up the error returned for FaultRPCError.
+	Code    ErrorCode
+	Message string
+
+	// Rate is the fraction of calls the fault is injected into. Zero
+	// injects it into every call.
+	Rate float64
+}
+
+// ChaosTransport is an http.RoundTripper that injects faults into RPC calls
+// for resilience testing. Faults are configured per method, JSON-RPC calls
+// are matched by their method name and the daemon's other RPC methods by
+// their path. The zero value forwards to http.DefaultTransport. Use it with
+// CallClient.SetHTTPClient:
+//
+//	chaos := monero.NewChaosTransport(nil)
+//	chaos.SetFault("transfer", monero.Fault{Kind: monero.FaultHTTPStatus, StatusCode: 502})
+//	wallet.SetHTTPClient(&http.Client{Transport: chaos})
+type ChaosTransport struct {
+	// Next handles requests that are forwarded, http.DefaultTransport if
+	// nil.
+	Next http.RoundTripper
+
+	mu     sync.Mutex
+	faults map[string]Fault
+	rand   *rand.Rand
+}
+
+// NewChaosTransport creates a ChaosTransport forwarding to next.
+func NewChaosTransport(next http.RoundTripper) *ChaosTransport {
+	return &ChaosTransport{Next: next}
+}
+
+// SetFault injects f into every call of method. It fails if f is a
+// FaultHTTPStatus without a StatusCode.
+func (t *ChaosTransport) SetFault(method string, f Fault) error {
+	if f.Kind == FaultHTTPStatus && f.StatusCode == 0 {
+		return fmt.Errorf("fault for %s has no status code", method)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.faults == nil {
+		t.faults = map[string]Fault{}
+	}
+	t.faults[method] = f
+	return nil
+}
+
+// ClearFault removes the fault for method.
+func (t *ChaosTransport) ClearFault(method string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.faults, method)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, err := requestMethod(req)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := t.fault(method)
+	if !ok {
+		return t.next().RoundTrip(req)
+	}
+
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch f.Kind {
+	case FaultHTTPStatus:
+		return chaosResponse(req, f.StatusCode, []byte(http.StatusText(f.StatusCode))), nil
+	case FaultMalformed:
+		return chaosResponse(req, http.StatusOK, []byte(`{"id":"0","jsonrpc":"2.0","result":{"status":OK}}`)), nil
+	case FaultRPCError:
+		data, err := json.Marshal(map[string]interface{}{
+			"id":      "0",
+			"jsonrpc": "2.0",
+			"error":   Error{Code: f.Code, Message: f.Message},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return chaosResponse(req, http.StatusOK, data), nil
+	}
+
+	resp, err := t.next().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch f.Kind {
+	case FaultDropConnection:
+		resp.Body = &droppingBody{body: resp.Body, remaining: f.Bytes}
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+	case FaultTruncated:
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		n := f.Bytes
+		if n <= 0 || n >= len(data) {
+			n = len(data) / 2
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(data[:n]))
+		resp.ContentLength = int64(n)
+		resp.Header.Set("Content-Length", strconv.Itoa(n))
+	}
+	return resp, nil
+}
+
+func (t *ChaosTransport) next() http.RoundTripper {
+	if t.Next != nil {
+		return t.Next
+	}
+	return http.DefaultTransport
+}
+
+// fault returns the fault to inject into a call of method, if any.
+func (t *ChaosTransport) fault(method string) (Fault, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, ok := t.faults[method]
+	if !ok {
+		f, ok = t.faults[AnyMethod]
+	}
+	if ok && f.Rate > 0 {
+		if t.rand == nil {
+			t.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		if t.rand.Float64() >= f.Rate {
+			return f, false
+		}
+	}
+	return f, ok
+}
+
+// requestMethod returns the RPC method of req and leaves its body intact.
+func requestMethod(req *http.Request) (string, error) {
+	if path.Base(req.URL.Path) != "json_rpc" {
+		return path.Base(req.URL.Path), nil
+	}
+	if req.Body == nil {
+		return "", nil
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	var c clientRequest
+	json.Unmarshal(data, &c)
+	return c.Method, nil
+}
+
+func chaosResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// droppingBody delivers remaining bytes of body and then fails like a
+// connection that was closed mid-body.
+type droppingBody struct {
+	body      io.ReadCloser
+	remaining int
+}
+
+func (b *droppingBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= n
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *droppingBody) Close() error {
+	return b.body.Close()
+}
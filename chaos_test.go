@@ -0,0 +1,133 @@
+package monero
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestChaosTransport(t *testing.T) {
+	srv := newFixtureServer(t, "wallet", true)
+	defer srv.Close()
+	chaos := NewChaosTransport(nil)
+	c := NewWalletClient(srv.URL+"/json_rpc", "user", "pass")
+	c.SetHTTPClient(&http.Client{Transport: chaos})
+
+	if _, err := c.GetBalances(); err != nil {
+		t.Fatalf("unexpected error without faults: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		fault Fault
+		check func(t *testing.T, err error)
+	}{
+		{
+			name:  "HTTPStatus",
+			fault: Fault{Kind: FaultHTTPStatus, StatusCode: http.StatusBadGateway},
+		},
+		{
+			name:  "Malformed",
+			fault: Fault{Kind: FaultMalformed},
+		},
+		{
+			name:  "Truncated",
+			fault: Fault{Kind: FaultTruncated},
+		},
+		{
+			name:  "DropConnection",
+			fault: Fault{Kind: FaultDropConnection, Bytes: 10},
+			check: func(t *testing.T, err error) {
+				if err != io.ErrUnexpectedEOF {
+					t.Errorf("got error %v, want %v", err, io.ErrUnexpectedEOF)
+				}
+			},
+		},
+		{
+			name:  "RPCError",
+			fault: Fault{Kind: FaultRPCError, Code: -13, Message: "No wallet file"},
+			check: func(t *testing.T, err error) {
+				if rpcErr, ok := err.(*Error); !ok || rpcErr.Code != -13 {
+					t.Errorf("got error %#v, want code -13", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chaos.SetFault("getbalance", tt.fault)
+			defer chaos.ClearFault("getbalance")
+
+			_, err := c.GetBalances()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.check != nil {
+				tt.check(t, err)
+			}
+			if _, err := c.GetHeight(); err != nil {
+				t.Errorf("fault leaked into another method: %v", err)
+			}
+		})
+	}
+}
+
+func TestChaosTransportLatency(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	chaos := NewChaosTransport(nil)
+	chaos.SetFault(AnyMethod, Fault{Latency: time.Second})
+	client := &http.Client{Transport: chaos, Timeout: 50 * time.Millisecond}
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+	c.SetHTTPClient(client)
+
+	start := time.Now()
+	if _, err := c.GetHeight(); err == nil {
+		t.Fatal("expected a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("latency ignored the request context, took %v", elapsed)
+	}
+
+	chaos.SetFault(AnyMethod, Fault{Latency: 10 * time.Millisecond})
+	c.SetHTTPClient(&http.Client{Transport: chaos})
+	if _, err := c.GetInfo(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestChaosTransportRate(t *testing.T) {
+	chaos := NewChaosTransport(nil)
+	chaos.SetFault("get_info", Fault{Kind: FaultMalformed, Rate: 0.5})
+	injected := 0
+	for i := 0; i < 1000; i++ {
+		if _, ok := chaos.fault("get_info"); ok {
+			injected++
+		}
+	}
+	if injected < 350 || injected > 650 {
+		t.Errorf("injected %d of 1000 faults at rate 0.5", injected)
+	}
+}
+
+func TestChaosTransportZeroValue(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	chaos := &ChaosTransport{}
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+	c.SetHTTPClient(&http.Client{Transport: chaos})
+
+	if _, err := c.GetHeight(); err != nil {
+		t.Fatalf("unexpected error without faults: %v", err)
+	}
+	if err := chaos.SetFault("get_height", Fault{Kind: FaultHTTPStatus}); err == nil {
+		t.Error("expected an error for a status fault without a status code")
+	}
+	if err := chaos.SetFault("get_height", Fault{Kind: FaultMalformed, Rate: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetHeight(); err == nil {
+		t.Error("expected an error")
+	}
+}
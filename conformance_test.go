@@ -0,0 +1,201 @@
+package monero
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The fixtures in testdata/fixtures are JSON-RPC responses of monerod and
+// monero-wallet-rpc; see the README there for where they come from. Each file
+// is named after the RPC method that returns it. Responses of the daemon's
+// "other" RPC methods are stored without the JSON-RPC envelope.
+
+// With -capture-daemon or -capture-wallet the fixture server forwards every
+// request to a live node instead and overwrites the fixture with its response,
+// so the tests run against, and record, what the node actually returns.
+var captureURLs = map[string]*string{
+	"daemon": flag.String("capture-daemon", "", "record the daemon fixtures from the monerod RPC at this URL"),
+	"wallet": flag.String("capture-wallet", "", "record the wallet fixtures from the monero-wallet-rpc at this URL"),
+}
+
+// conformanceCase describes a single client method under test.
+type conformanceCase struct {
+	name string
+
+	// method is the RPC method the client is expected to call.
+	method string
+
+	// params is the exact JSON the client is expected to send, "null" if
+	// the method takes no parameters.
+	params string
+
+	// call invokes the client method and returns its result, or nil for
+	// methods that return nothing but an error.
+	call func() (interface{}, error)
+
+	// unmodelled lists response fields the fixture contains that the
+	// client does not decode yet.
+	unmodelled []string
+}
+
+// fixtureServer serves fixtures for whatever RPC method it is asked for and
+// records the last request it received.
+type fixtureServer struct {
+	*httptest.Server
+
+	dir      string
+	auth     bool
+	upstream string
+
+	mu     sync.Mutex
+	method string
+	params json.RawMessage
+}
+
+func newFixtureServer(t *testing.T, dir string, auth bool) *fixtureServer {
+	s := &fixtureServer{dir: filepath.Join("testdata", "fixtures", dir), auth: auth}
+	if url := captureURLs[dir]; url != nil {
+		s.upstream = strings.TrimSuffix(*url, "/")
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request: %v", err)
+			return
+		}
+		if s.auth && !strings.HasPrefix(r.Header.Get("Authorization"), `Digest username="user"`) {
+			w.Header().Set("WWW-Authenticate", `Digest qop="auth",algorithm=MD5,realm="monero-rpc",nonce="Xv95vUKvFx+kxW0S4YR/fA==",stale=false`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		method := strings.TrimPrefix(r.URL.Path, "/")
+		params := json.RawMessage(body)
+		if method == "json_rpc" {
+			var req struct {
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := json.Unmarshal(body, &req); err != nil {
+				t.Errorf("decoding request: %v", err)
+				return
+			}
+			method, params = req.Method, req.Params
+		}
+		s.mu.Lock()
+		s.method, s.params = method, params
+		s.mu.Unlock()
+
+		if s.upstream != "" {
+			if err := s.capture(r.URL.Path, method, body); err != nil {
+				t.Errorf("capturing %s: %v", method, err)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+		}
+		data, err := ioutil.ReadFile(filepath.Join(s.dir, method+".json"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	return s
+}
+
+// capture sends a request to the upstream node and stores its response as
+// the fixture of method.
+func (s *fixtureServer) capture(path, method string, body []byte) error {
+	resp, err := http.Post(s.upstream+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, data)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	return ioutil.WriteFile(filepath.Join(s.dir, method+".json"), out.Bytes(), 0644)
+}
+
+func (s *fixtureServer) lastRequest() (string, json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.method, s.params
+}
+
+// runConformance runs each case against the fixture server the client
+// behind the cases talks to.
+func runConformance(t *testing.T, srv *fixtureServer, client *CallClient, cases []conformanceCase) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var drifts []SchemaDrift
+			client.OnSchemaDrift(func(d SchemaDrift) {
+				drifts = append(drifts, d)
+			})
+			defer client.OnSchemaDrift(nil)
+
+			got, err := tc.call()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			method, params := srv.lastRequest()
+			if method != tc.method {
+				t.Errorf("called method %q, want %q", method, tc.method)
+			}
+			if len(params) == 0 {
+				params = json.RawMessage("null")
+			}
+			var want bytes.Buffer
+			if err := json.Compact(&want, []byte(tc.params)); err != nil {
+				t.Fatalf("bad expected params %s: %v", tc.params, err)
+			}
+			if !bytes.Equal(params, want.Bytes()) {
+				t.Errorf("sent params\n\t%s\nwant\n\t%s", params, want.Bytes())
+			}
+
+			for _, d := range drifts {
+				if len(d.Missing) > 0 {
+					t.Errorf("fields missing from %s fixture: %v", d.Method, d.Missing)
+				}
+				for _, field := range d.Unknown {
+					if !containsString(tc.unmodelled, field) {
+						t.Errorf("unknown field in %s fixture: %s", d.Method, field)
+					}
+				}
+			}
+			if got != nil && reflect.ValueOf(got).IsZero() {
+				t.Errorf("result is empty: %#v", got)
+			}
+		})
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
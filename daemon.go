@@ -13,7 +13,7 @@ func NewDaemonClient(endpoint string) *DaemonClient {
 // GetHeight returns the height of the currently known longest chain
 func (c *DaemonClient) GetHeight() (BlockHeight, error) {
 	var bc BlockHeight
-	if err := c.Daemon("get_height", nil, &bc); err != nil {
+	if err := c.DaemonOther("get_height", nil, &bc); err != nil {
 		return bc, err
 	}
 	return bc, nil
@@ -31,27 +31,18 @@ func (c *DaemonClient) OnGetBlockHash(blockHeight int) (string, error) {
 
 // Get BlockTemplate
 func (c *DaemonClient) GetBlockTemplate(walletAddress string, reserveSize uint) (BlockTemplate, error) {
-	var bt BlockTemplate
-	req := struct {
-		walletAddress string `json:"wallet_address"`
-		reserveSize   uint   `json:"reserve_size"`
-	}{
-		walletAddress,
-		reserveSize,
-	}
-	if err := c.Daemon("getblocktemplate", req, &bt); err != nil {
-		return bt, err
-	}
-	return bt, nil
+	return c.GetBlockTemplateWith(BlockTemplateRequest{WalletAddress: walletAddress, ReserveSize: reserveSize})
 }
 
 // Submit a mined block to the network.
 func (c *DaemonClient) SubmitBlock(blockBlobData string) (string, error) {
-	var status string
-	if err := c.Daemon("submitblock", blockBlobData, &status); err != nil {
-		return status, err
+	var rep struct {
+		Status string `json:"status"`
 	}
-	return status, nil
+	if err := c.Daemon("submitblock", []string{blockBlobData}, &rep); err != nil {
+		return rep.Status, err
+	}
+	return rep.Status, nil
 }
 
 // Block header information for the most recent block is easily retrieved with this method. No inputs are needed.
@@ -97,11 +88,13 @@ func (c *DaemonClient) GetBlockHeaderByHeight(height uint64) (BlockHeaderRespons
 func (c *DaemonClient) GetBlock(height uint, hash string) (Block, error) {
 	var b Block
 	req := struct {
-		height uint   `json:"height, omitempty"`
-		hash   string `json:"hash, omitempty"`
+		Height *uint  `json:"height,omitempty"`
+		Hash   string `json:"hash,omitempty"`
 	}{
-		height,
-		hash,
+		Hash: hash,
+	}
+	if hash == "" {
+		req.Height = &height
 	}
 	if err := c.Daemon("getblock", req, &b); err != nil {
 		return b, err
@@ -136,13 +129,23 @@ func (c *DaemonClient) GetHardForkInfo() (HardForkInfo, error) {
 	return hi, nil
 }
 
-// Ban another node by IP.
+// Ban or unban other nodes by IP, host or IPv4 subnet.
 func (c *DaemonClient) SetBans(bans []Ban) (string, error) {
-	var status string
-	if err := c.Daemon("setbans", nil, &status); err != nil {
-		return status, err
+	var rep struct {
+		Status string `json:"status"`
 	}
-	return status, nil
+	for _, ban := range bans {
+		if err := validateBanHost(ban.Host); err != nil {
+			return "", err
+		}
+	}
+	req := struct {
+		Bans []Ban `json:"bans"`
+	}{bans}
+	if err := c.Daemon("setbans", req, &rep); err != nil {
+		return rep.Status, err
+	}
+	return rep.Status, nil
 }
 
 // Get bans
@@ -187,3 +190,44 @@ func (c *DaemonClient) GetBlockHashByHeight(height uint64) (blockHash string, er
 
 	return blockHash, nil
 }
+
+// GetTransactions looks up transactions by their hashes. With decodeAsJSON the
+// daemon also returns each transaction as JSON, see TransactionEntry.Transaction.
+// With prune only the pruned part of each transaction is returned and with
+// split the pruned and prunable parts are returned separately. Hashes the
+// daemon does not know are listed in MissedTx.
+func (c *DaemonClient) GetTransactions(txHashes []string, decodeAsJSON, prune, split bool) (TransactionsResponse, error) {
+	var tr TransactionsResponse
+	request := struct {
+		TxsHashes    []string `json:"txs_hashes"`
+		DecodeAsJSON bool     `json:"decode_as_json"`
+		Prune        bool     `json:"prune"`
+		Split        bool     `json:"split"`
+	}{txHashes, decodeAsJSON, prune, split}
+	if err := c.DaemonOther("get_transactions", &request, &tr); err != nil {
+		return tr, err
+	}
+	if err := statusError("get_transactions", tr.Status); err != nil {
+		return tr, err
+	}
+	return tr, nil
+}
+
+// SendRawTransaction broadcasts a signed transaction given as hex. With
+// doNotRelay the daemon only adds it to its own pool. If the daemon refuses
+// the transaction a *TxRejectedError is returned along with the response, use
+// errors.Is with ErrDoubleSpend, ErrFeeTooLow etc. to find out why.
+func (c *DaemonClient) SendRawTransaction(txHex string, doNotRelay bool) (SendRawTransactionResponse, error) {
+	var sr SendRawTransactionResponse
+	request := struct {
+		TxAsHex    string `json:"tx_as_hex"`
+		DoNotRelay bool   `json:"do_not_relay"`
+	}{txHex, doNotRelay}
+	if err := c.DaemonOther("send_raw_transaction", &request, &sr); err != nil {
+		return sr, err
+	}
+	if err := sr.rejection(doNotRelay); err != nil {
+		return sr, err
+	}
+	return sr, nil
+}
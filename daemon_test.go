@@ -0,0 +1,475 @@
+package monero
+
+import "testing"
+
+// headerUnmodelled lists the fields of the block header responses that are
+// not decoded.
+var headerUnmodelled = []string{"credits", "top_hash"}
+
+func TestDaemonConformance(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	runConformance(t, srv, c.CallClient, []conformanceCase{
+		{
+			name:       "GetHeight",
+			method:     "get_height",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetHeight() },
+			unmodelled: []string{"hash", "untrusted"},
+		},
+		{
+			name:   "OnGetBlockHash",
+			method: "on_getblockhash",
+			params: `[2286452]`,
+			call:   func() (interface{}, error) { return c.OnGetBlockHash(2286452) },
+		},
+		{
+			name:   "GetBlockTemplate",
+			method: "getblocktemplate",
+			params: `{"wallet_address":"44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns","reserve_size":60}`,
+			call: func() (interface{}, error) {
+				return c.GetBlockTemplate("44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns", 60)
+			},
+		},
+		{
+			name:   "GetBlockTemplateWith",
+			method: "getblocktemplate",
+			params: `{"wallet_address":"44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns","reserve_size":0,"extra_nonce":"0102030405060708","prev_block":"25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4"}`,
+			call: func() (interface{}, error) {
+				return c.GetBlockTemplateWith(BlockTemplateRequest{
+					WalletAddress: "44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns",
+					ExtraNonce:    "0102030405060708",
+					PrevBlock:     "25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4",
+				})
+			},
+		},
+		{
+			name:   "SubmitBlock",
+			method: "submitblock",
+			params: `["0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4c70702e102b3c78b0101fff7c68b0101a5f8ead2b0220237c25ce02c0c91be5066f2b13b67282462705c2b801ad5531837bbdc56cbab835f013c2b5022491ca87741cc3d18052a692f0da78ac3fb64f26a05d428b18ce999ba023c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000017cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d"]`,
+			call: func() (interface{}, error) {
+				return c.SubmitBlock("0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4c70702e102b3c78b0101fff7c68b0101a5f8ead2b0220237c25ce02c0c91be5066f2b13b67282462705c2b801ad5531837bbdc56cbab835f013c2b5022491ca87741cc3d18052a692f0da78ac3fb64f26a05d428b18ce999ba023c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000017cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d")
+			},
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "GetLastBlockHeader",
+			method:     "getlastblockheader",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetLastBlockHeader() },
+			unmodelled: headerUnmodelled,
+		},
+		{
+			name:   "GetBlockHeaderByHash",
+			method: "getblockheaderbyhash",
+			params: `{"hash":"25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4"}`,
+			call: func() (interface{}, error) {
+				return c.GetBlockHeaderByHash("25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4")
+			},
+			unmodelled: headerUnmodelled,
+		},
+		{
+			name:       "GetBlockHeaderByHeight",
+			method:     "getblockheaderbyheight",
+			params:     `{"height":2286454}`,
+			call:       func() (interface{}, error) { return c.GetBlockHeaderByHeight(2286454) },
+			unmodelled: headerUnmodelled,
+		},
+		{
+			name:       "GetBlockByHeight",
+			method:     "getblock",
+			params:     `{"height":2286454}`,
+			call:       func() (interface{}, error) { return c.GetBlock(2286454, "") },
+			unmodelled: headerUnmodelled,
+		},
+		{
+			name:   "GetBlockByHash",
+			method: "getblock",
+			params: `{"hash":"25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4"}`,
+			call: func() (interface{}, error) {
+				return c.GetBlock(0, "25e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f4")
+			},
+			unmodelled: headerUnmodelled,
+		},
+		{
+			name:   "GetConnections",
+			method: "get_connections",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetConnections() },
+			unmodelled: []string{
+				"connections[].address",
+				"connections[].address_type",
+				"connections[].connection_id",
+				"connections[].host",
+				"connections[].pruning_seed",
+				"connections[].rpc_credits_per_hash",
+				"connections[].rpc_port",
+				"connections[].support_flags",
+				"untrusted",
+			},
+		},
+		{
+			name:       "GetInfo",
+			method:     "get_info",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetInfo() },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:       "GetHardForkInfo",
+			method:     "hard_fork_info",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetHardForkInfo() },
+			unmodelled: []string{"credits", "top_hash", "untrusted"},
+		},
+		{
+			name:   "SetBans",
+			method: "setbans",
+			params: `{"bans":[{"ip":838969536,"ban":true,"seconds":30}]}`,
+			call: func() (interface{}, error) {
+				return c.SetBans([]Ban{NewBanRequest(838969536, true, 30)})
+			},
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "GetBans",
+			method:     "getbans",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetBans() },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:   "GenerateBlocks",
+			method: "generateblocks",
+			params: `{"amount_of_blocks":1,"wallet_address":"44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns"}`,
+			call: func() (interface{}, error) {
+				return c.GenerateBlocks("44GBHzv6ZyQdJkjqZje6KLZ3xSyN1hBSFAnLP6EAqJtCRVzMzZmeXTC2AHKDS9aEDTRKmo6a6o9r9j86pYfhCWDkKjbtcns", 1)
+			},
+			unmodelled: []string{"blocks", "untrusted"},
+		},
+		{
+			name:       "GetBlockHeadersRange",
+			method:     "getblockheadersrange",
+			params:     `{"start_height":2286452,"end_height":2286454}`,
+			call:       func() (interface{}, error) { return c.GetBlockHeadersRange(2286452, 2286454) },
+			unmodelled: []string{"credits", "top_hash", "untrusted"},
+		},
+		{
+			name:   "GetOutputHistogram",
+			method: "get_output_histogram",
+			params: `{"amounts":[20000000000,1000000000000],"min_count":0,"max_count":0,"unlocked":true,"recent_cutoff":0}`,
+			call: func() (interface{}, error) {
+				return c.GetOutputHistogram(OutputHistogramRequest{Amounts: []uint64{20000000000, 1000000000000}, Unlocked: true})
+			},
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:   "GetOutputDistribution",
+			method: "get_output_distribution",
+			params: `{"amounts":[0],"from_height":2286440,"to_height":2286454,"cumulative":true,"binary":true,"compress":true}`,
+			call: func() (interface{}, error) {
+				return c.GetOutputDistribution(OutputDistributionRequest{
+					Amounts:    []uint64{0},
+					FromHeight: 2286440,
+					ToHeight:   2286454,
+					Cumulative: true,
+					Binary:     true,
+					Compress:   true,
+				})
+			},
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:       "GetCoinbaseTxSum",
+			method:     "get_coinbase_tx_sum",
+			params:     `{"height":0,"count":2286455}`,
+			call:       func() (interface{}, error) { return c.GetCoinbaseTxSum(0, 2286455) },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:       "GetAlternateChains",
+			method:     "get_alternate_chains",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetAlternateChains() },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:   "SyncInfo",
+			method: "sync_info",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.SyncInfo() },
+			unmodelled: []string{
+				"credits",
+				"peers[].info.address",
+				"peers[].info.address_type",
+				"peers[].info.connection_id",
+				"peers[].info.host",
+				"peers[].info.pruning_seed",
+				"peers[].info.rpc_credits_per_hash",
+				"peers[].info.rpc_port",
+				"peers[].info.support_flags",
+				"top_hash",
+			},
+		},
+		{
+			name:   "IsKeyImageSpent",
+			method: "is_key_image_spent",
+			params: `{"key_images":["2f8b41eb0df6ee33b08f1cdaf5174b1e2478249a22e490df2b894db5c265a722","27328c73bbca10dda9c2d4af588c9a85b3145ac7e8e83d7237a9b5068d9c8efd","c97d3c2f0e19f299b453db95d7ff8da4f92103888ae4be331ca007f56dfdcb20"]}`,
+			call: func() (interface{}, error) {
+				return c.IsKeyImageSpent([]string{
+					"2f8b41eb0df6ee33b08f1cdaf5174b1e2478249a22e490df2b894db5c265a722",
+					"27328c73bbca10dda9c2d4af588c9a85b3145ac7e8e83d7237a9b5068d9c8efd",
+					"c97d3c2f0e19f299b453db95d7ff8da4f92103888ae4be331ca007f56dfdcb20",
+				})
+			},
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:   "SetBansHosts",
+			method: "setbans",
+			params: `{"bans":[{"host":"192.0.2.0/24","ban":true,"seconds":3600},{"host":"198.51.100.7","seconds":0}]}`,
+			call: func() (interface{}, error) {
+				return c.SetBans([]Ban{NewHostBan("192.0.2.0/24", 3600), NewUnban("198.51.100.7")})
+			},
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:   "Banned",
+			method: "banned",
+			params: `{"address":"192.0.2.51"}`,
+			call: func() (interface{}, error) {
+				banned, seconds, err := c.Banned("192.0.2.51")
+				if !banned || seconds != 7148 {
+					t.Errorf("got %v, %d", banned, seconds)
+				}
+				return nil, err
+			},
+		},
+		{
+			name:   "GetPeerList",
+			method: "get_peer_list",
+			params: `{"public_only":true,"include_blocked":false}`,
+			call:   func() (interface{}, error) { return c.GetPeerList(true, false) },
+		},
+		{
+			name:   "GetPublicNodes",
+			method: "get_public_nodes",
+			params: `{"gray":true,"white":true,"include_blocked":false}`,
+			call:   func() (interface{}, error) { return c.GetPublicNodes(true, true, false) },
+		},
+		{
+			name:   "SetBootstrapDaemon",
+			method: "set_bootstrap_daemon",
+			params: `{"address":"203.0.113.34:18089","proxy":"127.0.0.1:9050"}`,
+			call: func() (interface{}, error) {
+				return nil, c.SetBootstrapDaemon("203.0.113.34:18089", "", "", "127.0.0.1:9050")
+			},
+		},
+		{
+			name:       "InPeers",
+			method:     "in_peers",
+			params:     `{"set":true,"in_peers":64}`,
+			call:       func() (interface{}, error) { return c.InPeers(64) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "OutPeers",
+			method:     "out_peers",
+			params:     `{"set":true,"out_peers":32}`,
+			call:       func() (interface{}, error) { return c.OutPeers(32) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "SetLimit",
+			method:     "set_limit",
+			params:     `{"limit_down":8192,"limit_up":2048}`,
+			call:       func() (interface{}, error) { return c.SetLimit(8192, 2048) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "SaveBC",
+			method:     "save_bc",
+			params:     `null`,
+			call:       func() (interface{}, error) { return nil, c.SaveBC() },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "SetLogLevel",
+			method:     "set_log_level",
+			params:     `{"level":2}`,
+			call:       func() (interface{}, error) { return nil, c.SetLogLevel(2) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "SetLogCategories",
+			method:     "set_log_categories",
+			params:     `{"categories":"*:WARNING,net:INFO"}`,
+			call:       func() (interface{}, error) { return c.SetLogCategories("*:WARNING,net:INFO") },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "SetLogHashRate",
+			method:     "set_log_hash_rate",
+			params:     `{"visible":true}`,
+			call:       func() (interface{}, error) { return nil, c.SetLogHashRate(true) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "PopBlocks",
+			method:     "pop_blocks",
+			params:     `{"nblocks":5}`,
+			call:       func() (interface{}, error) { return c.PopBlocks(5) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:   "PruneBlockchain",
+			method: "prune_blockchain",
+			params: `{"check":true}`,
+			call:   func() (interface{}, error) { return c.PruneBlockchain(true) },
+		},
+		{
+			name:       "StopDaemon",
+			method:     "stop_daemon",
+			params:     `null`,
+			call:       func() (interface{}, error) { return nil, c.StopDaemon() },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:   "Update",
+			method: "update",
+			params: `{"command":"check"}`,
+			call:   func() (interface{}, error) { return c.Update(UpdateCheck, "") },
+		},
+		{
+			name:   "GetNetStats",
+			method: "get_net_stats",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetNetStats() },
+		},
+		{
+			name:       "GetLimit",
+			method:     "get_limit",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetLimit() },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "StartMining",
+			method:     "start_mining",
+			params:     `{"miner_address":"47xu3gQpF569au9C2ajo5SSMrWji6xnoE5vhr94EzFRaKAGw6hEGFXYAwVADKuRpzsjiU1PtmaVgcjUJF89ghGPhUXkndHc","threads_count":4,"do_background_mining":false,"ignore_battery":true}`,
+			call:       func() (interface{}, error) { return nil, c.StartMining(miningAddress, 4, false, true) },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:       "StopMining",
+			method:     "stop_mining",
+			params:     `null`,
+			call:       func() (interface{}, error) { return nil, c.StopMining() },
+			unmodelled: []string{"untrusted"},
+		},
+		{
+			name:   "MiningStatus",
+			method: "mining_status",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.MiningStatus() },
+		},
+		{
+			name:   "GetMinerData",
+			method: "get_miner_data",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetMinerData() },
+		},
+		{
+			name:   "CalcPow",
+			method: "calc_pow",
+			params: `{"major_version":14,"height":2286455,"block_blob":"0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f400000000ac27ea7934c47dbfc3adaf92854a0cc1439afb31a172631a20de3059ded657ba02","seed_hash":"6e1d8d16145506cffd94b8f3f20d8da7a9d7a3ab5c7627b780afc25a6e97813f"}`,
+			call: func() (interface{}, error) {
+				return c.CalcPow(14, 2286455, "0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f400000000ac27ea7934c47dbfc3adaf92854a0cc1439afb31a172631a20de3059ded657ba02", "6e1d8d16145506cffd94b8f3f20d8da7a9d7a3ab5c7627b780afc25a6e97813f")
+			},
+		},
+		{
+			name:   "AddAuxPow",
+			method: "add_aux_pow",
+			params: `{"blocktemplate_blob":"0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f40000000002b3c78b0101fff7c68b0101a5f8ead2b0220237c25ce02c0c91be5066f2b13b67282462705c2b801ad5531837bbdc56cbab835f013c2b5022491ca87741cc3d18052a692f0da78ac3fb64f26a05d428b18ce999ba023c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000017cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d","aux_pow":[{"id":"c992d2614026efccf6636e9f3e83cd4dfaa760729f2e5316f4c8bfe5d6a1ad0e","hash":"4e1d9bf12eaf95bbadb3747a192d37a5403c8d4ab9d0f7abfaf565fbfc85b563"},{"id":"8c664297d0432f5e0206e8e0359b48d42130205844d3e0632bf44ba71dc93a0b","hash":"1116e48e9ac8a5ecc8a1135f36928b04d927f83fb7624a46a946ad94fd5be21a"}]}`,
+			call: func() (interface{}, error) {
+				return c.AddAuxPow("0e0efe8ada800625e97945496bd18ce72b8e1c298a3f72c651b90272e0d273776e282de6f301f40000000002b3c78b0101fff7c68b0101a5f8ead2b0220237c25ce02c0c91be5066f2b13b67282462705c2b801ad5531837bbdc56cbab835f013c2b5022491ca87741cc3d18052a692f0da78ac3fb64f26a05d428b18ce999ba023c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000017cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d", []AuxPow{
+					{ID: "c992d2614026efccf6636e9f3e83cd4dfaa760729f2e5316f4c8bfe5d6a1ad0e", Hash: "4e1d9bf12eaf95bbadb3747a192d37a5403c8d4ab9d0f7abfaf565fbfc85b563"},
+					{ID: "8c664297d0432f5e0206e8e0359b48d42130205844d3e0632bf44ba71dc93a0b", Hash: "1116e48e9ac8a5ecc8a1135f36928b04d927f83fb7624a46a946ad94fd5be21a"},
+				})
+			},
+		},
+		{
+			name:   "GetBlockHashByHeight",
+			method: "on_getblockhash",
+			params: `[2286452]`,
+			call:   func() (interface{}, error) { return c.GetBlockHashByHeight(2286452) },
+		},
+		{
+			name:   "GetTransactions",
+			method: "get_transactions",
+			params: `{"txs_hashes":["9ff04fc01326ef7b1d7f3d883de4ecb27927d283bd3be7474198b61ec4521597","7cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d","9f5b12146dd4709ba2fdcdfa5d5f8045d226da49c0d97b7648d3203687ce0d61","e887e7ab4f6ccd9c3c2c705fc1fd7f32a66b5db18c6edf726631efa7033d25b7"],"decode_as_json":true,"prune":false,"split":false}`,
+			call: func() (interface{}, error) {
+				return c.GetTransactions(testTxHashes, true, false, false)
+			},
+			unmodelled: []string{"credits", "top_hash", "txs_as_hex", "txs_as_json"},
+		},
+		{
+			name:       "SendRawTransaction",
+			method:     "send_raw_transaction",
+			params:     `{"tx_as_hex":"` + testTxBlob + `","do_not_relay":false}`,
+			call:       func() (interface{}, error) { return c.SendRawTransaction(testTxBlob, false) },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:       "GetTransactionPool",
+			method:     "get_transaction_pool",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetTransactionPool() },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:       "GetTransactionPoolHashes",
+			method:     "get_transaction_pool_hashes",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetTransactionPoolHashes() },
+			unmodelled: []string{"credits", "top_hash", "untrusted"},
+		},
+		{
+			name:       "GetTransactionPoolStats",
+			method:     "get_transaction_pool_stats",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetTransactionPoolStats() },
+			unmodelled: []string{"credits", "top_hash", "untrusted"},
+		},
+		{
+			name:       "GetTxPoolBacklog",
+			method:     "get_txpool_backlog",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetTxPoolBacklog() },
+			unmodelled: []string{"credits", "top_hash", "untrusted"},
+		},
+		{
+			name:       "GetFeeEstimate",
+			method:     "get_fee_estimate",
+			params:     `{"grace_blocks":10}`,
+			call:       func() (interface{}, error) { return c.GetFeeEstimate(10) },
+			unmodelled: []string{"credits", "top_hash"},
+		},
+		{
+			name:   "FlushTxPool",
+			method: "flush_txpool",
+			params: `{}`,
+			call:   func() (interface{}, error) { return nil, c.FlushTxPool() },
+		},
+		{
+			name:   "FlushTxPoolTxIDs",
+			method: "flush_txpool",
+			params: `{"txids":["d9ec9577e29f0113b241fc0308bb8fa7986b6919b211e608072ce71f494816fa"]}`,
+			call: func() (interface{}, error) {
+				return nil, c.FlushTxPool("d9ec9577e29f0113b241fc0308bb8fa7986b6919b211e608072ce71f494816fa")
+			},
+		},
+	})
+}
@@ -0,0 +1,144 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Difficulty is a 128-bit mining difficulty. The daemon sends difficulties as
+// 0x prefixed hex strings such as wide_difficulty, or split into their low 64
+// bits and difficulty_top64.
+type Difficulty struct {
+	Hi uint64
+	Lo uint64
+}
+
+// NewDifficulty returns the difficulty with the given top and low 64 bits.
+func NewDifficulty(top64, low uint64) Difficulty {
+	return Difficulty{Hi: top64, Lo: low}
+}
+
+// ParseDifficulty parses a difficulty in the daemon's 0x prefixed hex format.
+func ParseDifficulty(s string) (Difficulty, error) {
+	v, err := parseWideHex(s)
+	if err != nil {
+		return Difficulty{}, err
+	}
+	if v.BitLen() > 128 {
+		return Difficulty{}, fmt.Errorf("difficulty %s exceeds 128 bits", s)
+	}
+	return difficultyFromBig(v), nil
+}
+
+func difficultyFromBig(v *big.Int) Difficulty {
+	lo := new(big.Int).And(v, new(big.Int).SetUint64(^uint64(0)))
+	return Difficulty{Hi: new(big.Int).Rsh(v, 64).Uint64(), Lo: lo.Uint64()}
+}
+
+// Big returns the difficulty as a big.Int.
+func (d Difficulty) Big() *big.Int {
+	v := new(big.Int).SetUint64(d.Hi)
+	v.Lsh(v, 64)
+	return v.Or(v, new(big.Int).SetUint64(d.Lo))
+}
+
+// IsUint64 reports whether the difficulty fits in 64 bits.
+func (d Difficulty) IsUint64() bool {
+	return d.Hi == 0
+}
+
+// Cmp compares d and o and returns -1, 0 or +1.
+func (d Difficulty) Cmp(o Difficulty) int {
+	switch {
+	case d.Hi < o.Hi, d.Hi == o.Hi && d.Lo < o.Lo:
+		return -1
+	case d == o:
+		return 0
+	}
+	return 1
+}
+
+// Add returns d + o, wrapping around at 128 bits.
+func (d Difficulty) Add(o Difficulty) Difficulty {
+	lo, carry := bits.Add64(d.Lo, o.Lo, 0)
+	hi, _ := bits.Add64(d.Hi, o.Hi, carry)
+	return Difficulty{Hi: hi, Lo: lo}
+}
+
+// Sub returns d - o, wrapping around at 128 bits.
+func (d Difficulty) Sub(o Difficulty) Difficulty {
+	lo, borrow := bits.Sub64(d.Lo, o.Lo, 0)
+	hi, _ := bits.Sub64(d.Hi, o.Hi, borrow)
+	return Difficulty{Hi: hi, Lo: lo}
+}
+
+// Float64 returns the nearest float64 to the difficulty.
+func (d Difficulty) Float64() float64 {
+	f, _ := new(big.Float).SetInt(d.Big()).Float64()
+	return f
+}
+
+// String formats the difficulty the way the daemon does, as 0x prefixed hex.
+func (d Difficulty) String() string {
+	if d.Hi == 0 {
+		return fmt.Sprintf("0x%x", d.Lo)
+	}
+	return fmt.Sprintf("0x%x%016x", d.Hi, d.Lo)
+}
+
+// MarshalJSON encodes the difficulty as a hex string.
+func (d Difficulty) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a hex string or a plain number.
+func (d *Difficulty) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		v, err := ParseDifficulty(s)
+		if err != nil {
+			return err
+		}
+		*d = v
+		return nil
+	}
+	var n uint64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid difficulty %s", data)
+	}
+	*d = Difficulty{Lo: n}
+	return nil
+}
+
+// wideDifficulty returns wide, or the difficulty of its top and low 64 bits
+// for daemons that do not send the hex string.
+func wideDifficulty(wide Difficulty, top64, low uint64) Difficulty {
+	if wide != (Difficulty{}) {
+		return wide
+	}
+	return NewDifficulty(top64, low)
+}
+
+// Difficulty128 returns the full difficulty of the block.
+func (h BlockHeader) Difficulty128() Difficulty {
+	return wideDifficulty(h.WideDifficulty, h.DifficultyTop64, h.Difficulty)
+}
+
+// CumulativeDifficulty128 returns the full cumulative difficulty of the chain
+// up to the block.
+func (h BlockHeader) CumulativeDifficulty128() Difficulty {
+	return wideDifficulty(h.WideCumulativeDifficulty, h.CumulativeDifficultyTop64, h.CumulativeDifficulty)
+}
+
+// Difficulty128 returns the full difficulty of the next block.
+func (i Info) Difficulty128() Difficulty {
+	return wideDifficulty(i.WideDifficulty, i.DifficultyTop64, i.Difficulty)
+}
+
+// CumulativeDifficulty128 returns the full cumulative difficulty of the
+// chain.
+func (i Info) CumulativeDifficulty128() Difficulty {
+	return wideDifficulty(i.WideCumulativeDifficulty, i.CumulativeDifficultyTop64, i.CumulativeDifficulty)
+}
@@ -0,0 +1,74 @@
+package monero
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDifficulty(t *testing.T) {
+	for _, tc := range []struct {
+		hex string
+		d   Difficulty
+	}{
+		{"0x43fdea455f", Difficulty{Lo: 0x43fdea455f}},
+		{"0x0", Difficulty{}},
+		{"0x1ffffffffffffffff", Difficulty{Hi: 1, Lo: ^uint64(0)}},
+		{"0x10000000000000000000000000000000", Difficulty{Hi: 1 << 60}},
+	} {
+		d, err := ParseDifficulty(tc.hex)
+		if err != nil || d != tc.d {
+			t.Errorf("%s: got %+v, %v, want %+v", tc.hex, d, err, tc.d)
+		}
+		if got := d.String(); got != tc.hex {
+			t.Errorf("%+v: formatted as %s, want %s", d, got, tc.hex)
+		}
+		if got := d.Big().Text(16); "0x"+got != tc.hex {
+			t.Errorf("%+v: big value 0x%s, want %s", d, got, tc.hex)
+		}
+	}
+	for _, s := range []string{"", "0x", "0xg1", "0x100000000000000000000000000000000", "-0x1"} {
+		if _, err := ParseDifficulty(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+
+	var got struct {
+		Wide  Difficulty `json:"wide"`
+		Plain Difficulty `json:"plain"`
+	}
+	if err := json.Unmarshal([]byte(`{"wide":"0x1ffffffffffffffff","plain":283305047039}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Wide != NewDifficulty(1, ^uint64(0)) || got.Plain != NewDifficulty(0, 283305047039) {
+		t.Errorf("got %+v", got)
+	}
+	if sum := got.Wide.Add(got.Plain); sum != NewDifficulty(2, 283305047038) || sum.Cmp(got.Wide) != 1 || got.Plain.Cmp(sum) != -1 || sum.Cmp(sum) != 0 {
+		t.Errorf("got sum %+v", sum)
+	}
+	if data, _ := json.Marshal(got.Wide); string(data) != `"0x1ffffffffffffffff"` {
+		t.Errorf("marshalled as %s", data)
+	}
+}
+
+func TestDifficulty128(t *testing.T) {
+	d := NewDifficulty(1, 5)
+	if got := d.Sub(NewDifficulty(0, 6)); got != NewDifficulty(0, ^uint64(0)) {
+		t.Errorf("got difference %+v", got)
+	}
+	if got := d.Float64(); got != 18446744073709551621 {
+		t.Errorf("got float %v", got)
+	}
+
+	// Daemons before wide difficulties only send the split values.
+	h := BlockHeader{Difficulty: 5, DifficultyTop64: 1, CumulativeDifficulty: 7, CumulativeDifficultyTop64: 2}
+	if got := h.Difficulty128(); got != d {
+		t.Errorf("got difficulty %+v", got)
+	}
+	if got := h.CumulativeDifficulty128(); got != NewDifficulty(2, 7) {
+		t.Errorf("got cumulative difficulty %+v", got)
+	}
+	h.WideDifficulty = NewDifficulty(3, 0)
+	if got := h.Difficulty128(); got != h.WideDifficulty {
+		t.Errorf("got difficulty %+v, want the wide one", got)
+	}
+}
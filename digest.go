@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 )
@@ -270,21 +271,235 @@ func timeoutDialer(cTimeout time.Duration, rwTimeout time.Duration) func(net, ad
 	}
 }
 
+// DigestAuthParams returns the parameters of the first Digest challenge in
+// the WWW-Authenticate headers of r, or nil if there is none.
 func DigestAuthParams(r *http.Response) map[string]string {
-	s := strings.SplitN(r.Header.Get("Www-Authenticate"), " ", 2)
-	if len(s) != 2 || s[0] != "Digest" {
-		return nil
+	for _, header := range r.Header.Values("Www-Authenticate") {
+		challenges, err := ParseChallenges(header)
+		if err != nil {
+			continue
+		}
+		for _, c := range challenges {
+			if strings.EqualFold(c.Scheme, "Digest") {
+				return c.Params
+			}
+		}
+	}
+	return nil
+}
+
+// AuthChallenge is a single authentication challenge from a WWW-Authenticate
+// header.
+type AuthChallenge struct {
+	// Scheme is the authentication scheme, e.g. "Digest".
+	Scheme string
+
+	// Token68 is set for challenges of the form `Scheme token68`.
+	Token68 string
+
+	// Params holds the auth-params of the challenge, keyed by their
+	// lowercased names, with quoted values unquoted.
+	Params map[string]string
+}
+
+// String formats the challenge as it would appear in a header.
+func (c AuthChallenge) String() string {
+	if c.Token68 != "" {
+		return c.Scheme + " " + c.Token68
+	}
+	names := make([]string, 0, len(c.Params))
+	for name := range c.Params {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	params := make([]string, len(names))
+	for i, name := range names {
+		params[i] = name + "=" + quoteString(c.Params[name])
+	}
+	if len(params) == 0 {
+		return c.Scheme
+	}
+	return c.Scheme + " " + strings.Join(params, ", ")
+}
+
+// ParseChallenges parses the value of a WWW-Authenticate header as defined by
+// RFC 7235 section 4.1. A single value can hold several comma separated
+// challenges, and quoted parameter values may contain commas and escaped
+// quotes.
+func ParseChallenges(header string) ([]AuthChallenge, error) {
+	p := &challengeParser{s: header}
+	var challenges []AuthChallenge
+	for {
+		p.skipListSeparators()
+		if p.eof() {
+			break
+		}
+		start := p.pos
+		name := p.token()
+		if name == "" {
+			return nil, fmt.Errorf("invalid character %q at offset %d in challenge", p.s[p.pos], p.pos)
+		}
+
+		// an auth-param belongs to the current challenge, anything else
+		// starts a new one
+		p.skipSpace()
+		if len(challenges) > 0 && p.peek() == '=' {
+			if challenges[len(challenges)-1].Token68 != "" {
+				return nil, fmt.Errorf("unexpected parameter %q after token68 at offset %d in challenge", name, start)
+			}
+			p.pos++
+			p.skipSpace()
+			value, err := p.paramValue()
+			if err != nil {
+				return nil, err
+			}
+			challenges[len(challenges)-1].Params[strings.ToLower(name)] = value
+			if err := p.endOfElement(); err != nil {
+				return nil, err
+			}
+			continue
+		}
 
-	result := map[string]string{}
-	for _, kv := range strings.Split(s[1], ",") {
-		parts := strings.SplitN(kv, "=", 2)
-		if len(parts) != 2 {
+		p.pos = start + len(name)
+		challenges = append(challenges, AuthChallenge{Scheme: name, Params: map[string]string{}})
+		if p.skipSpace() == 0 {
+			if err := p.endOfElement(); err != nil {
+				return nil, err
+			}
 			continue
 		}
-		result[strings.Trim(parts[0], "\" ")] = strings.Trim(parts[1], "\" ")
+		if token68, ok := p.token68(); ok {
+			challenges[len(challenges)-1].Token68 = token68
+		}
+	}
+	return challenges, nil
+}
+
+type challengeParser struct {
+	s   string
+	pos int
+}
+
+func (p *challengeParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *challengeParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *challengeParser) skipSpace() int {
+	start := p.pos
+	for !p.eof() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+	return p.pos - start
+}
+
+func (p *challengeParser) skipListSeparators() {
+	for !p.eof() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == ',') {
+		p.pos++
+	}
+}
+
+// endOfElement consumes optional whitespace and expects the end of the
+// header or a comma.
+func (p *challengeParser) endOfElement() error {
+	p.skipSpace()
+	if !p.eof() && p.s[p.pos] != ',' {
+		return fmt.Errorf("unexpected character %q at offset %d in challenge", p.s[p.pos], p.pos)
+	}
+	return nil
+}
+
+func (p *challengeParser) token() string {
+	start := p.pos
+	for !p.eof() && isTokenChar(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// token68 consumes a token68 if one follows and is the only thing before the
+// next comma, otherwise it leaves the position unchanged.
+func (p *challengeParser) token68() (string, bool) {
+	start := p.pos
+	for !p.eof() && isToken68Char(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", false
+	}
+	for !p.eof() && p.s[p.pos] == '=' {
+		p.pos++
+	}
+	end := p.pos
+	p.skipSpace()
+	if p.eof() || p.s[p.pos] == ',' {
+		return p.s[start:end], true
+	}
+	p.pos = start
+	return "", false
+}
+
+func (p *challengeParser) paramValue() (string, error) {
+	if p.peek() != '"' {
+		value := p.token()
+		if value == "" {
+			return "", fmt.Errorf("missing parameter value at offset %d in challenge", p.pos)
+		}
+		return value, nil
+	}
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				break
+			}
+			b.WriteByte(p.s[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted string at offset %d in challenge", start)
+}
+
+func isTokenChar(c byte) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
+
+func isToken68Char(c byte) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.IndexByte("-._~+/", c) >= 0
+}
+
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
 	}
-	return result
+	b.WriteByte('"')
+	return b.String()
 }
 
 func RandomKey() string {
@@ -0,0 +1,111 @@
+package monero
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseChallenges(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []AuthChallenge
+	}{
+		{
+			header: `Digest qop="auth",algorithm=MD5,realm="monero-rpc",nonce="Xv95vUKvFx+kxW0S4YR/fA==",stale=false`,
+			want: []AuthChallenge{{Scheme: "Digest", Params: map[string]string{
+				"qop": "auth", "algorithm": "MD5", "realm": "monero-rpc", "nonce": "Xv95vUKvFx+kxW0S4YR/fA==", "stale": "false",
+			}}},
+		},
+		{
+			header: `Digest qop="auth",algorithm=MD5,realm="monero-rpc",nonce="a",stale=false, Digest qop="auth",algorithm=MD5-sess,realm="monero-rpc",nonce="b",stale=false`,
+			want: []AuthChallenge{
+				{Scheme: "Digest", Params: map[string]string{"qop": "auth", "algorithm": "MD5", "realm": "monero-rpc", "nonce": "a", "stale": "false"}},
+				{Scheme: "Digest", Params: map[string]string{"qop": "auth", "algorithm": "MD5-sess", "realm": "monero-rpc", "nonce": "b", "stale": "false"}},
+			},
+		},
+		{
+			header: `Newauth realm="apps", type=1, title="Login to \"apps\"", Basic realm="simple"`,
+			want: []AuthChallenge{
+				{Scheme: "Newauth", Params: map[string]string{"realm": "apps", "type": "1", "title": `Login to "apps"`}},
+				{Scheme: "Basic", Params: map[string]string{"realm": "simple"}},
+			},
+		},
+		{
+			header: `Digest realm="a, b", qop="auth,auth-int", NONCE=abc`,
+			want:   []AuthChallenge{{Scheme: "Digest", Params: map[string]string{"realm": "a, b", "qop": "auth,auth-int", "nonce": "abc"}}},
+		},
+		{
+			header: `Negotiate dGVzdA==, Basic`,
+			want: []AuthChallenge{
+				{Scheme: "Negotiate", Token68: "dGVzdA==", Params: map[string]string{}},
+				{Scheme: "Basic", Params: map[string]string{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseChallenges(tt.header)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.header, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %+v\nwant %+v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestParseChallengesInvalid(t *testing.T) {
+	for _, header := range []string{
+		`Digest realm="unterminated`,
+		`Digest nonce="a", realm=`,
+		`Digest realm="a" nonce="b"`,
+		`Negotiate dGVzdA==, realm="a"`,
+		`"Digest"`,
+	} {
+		if _, err := ParseChallenges(header); err == nil {
+			t.Errorf("%s: expected an error", header)
+		}
+	}
+}
+
+func TestDigestAuthParamsMultipleHeaders(t *testing.T) {
+	r := &http.Response{Header: http.Header{}}
+	r.Header.Add("WWW-Authenticate", `Basic realm="monero-rpc"`)
+	r.Header.Add("WWW-Authenticate", `Digest realm="monero-rpc", nonce="n, with comma", qop="auth"`)
+	got := DigestAuthParams(r)
+	if got["nonce"] != "n, with comma" || got["qop"] != "auth" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func FuzzDigestAuthParams(f *testing.F) {
+	f.Add(`Digest qop="auth",algorithm=MD5,realm="monero-rpc",nonce="Xv95vUKvFx+kxW0S4YR/fA==",stale=false`)
+	f.Add(`Digest qop="auth",algorithm=MD5,realm="monero-rpc",nonce="a",stale=false, Digest qop="auth",algorithm=MD5-sess,realm="monero-rpc",nonce="b",stale=false`)
+	f.Add(`Newauth realm="apps", type=1, title="Login to \"apps\"", Basic realm="simple"`)
+	f.Add(`Digest realm="a, b", qop="auth,auth-int"`)
+	f.Add(`Negotiate dGVzdA==, Basic`)
+	f.Add(`Digest realm="unterminated`)
+	f.Add(`,,, Digest ,, realm = "x" ,`)
+	f.Fuzz(func(t *testing.T, header string) {
+		r := &http.Response{Header: http.Header{"Www-Authenticate": {header}}}
+		DigestAuthParams(r)
+
+		challenges, err := ParseChallenges(header)
+		if err != nil {
+			return
+		}
+		formatted := make([]string, len(challenges))
+		for i, c := range challenges {
+			formatted[i] = c.String()
+		}
+		again, err := ParseChallenges(strings.Join(formatted, ", "))
+		if err != nil {
+			t.Fatalf("reparsing %q: %v", formatted, err)
+		}
+		if !reflect.DeepEqual(challenges, again) {
+			t.Fatalf("round trip mismatch:\n%+v\n%+v", challenges, again)
+		}
+	})
+}
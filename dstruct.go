@@ -6,6 +6,7 @@ package monero
 
 import (
 	"encoding/json"
+	"net"
 )
 
 // BlockHeight ...
@@ -18,64 +19,110 @@ type BlockHeight struct {
 
 // BlockTemplate
 // blocktemplate_blob - string; Blob on which to try to mine a new block.
+// blockhashing_blob - string; Blob the proof of work is computed on, with a zero nonce.
 // difficulty - unsigned int; Difficulty of next block.
+// difficulty_top64 - unsigned int; Most significant 64 bits of the difficulty.
+// wide_difficulty - string; Difficulty of next block as a hex string.
+// expected_reward - unsigned int; Coinbase reward of the block in atomic units.
 // height - unsigned int; Height on which to mine.
 // prev_hash - string; Hash of the most recent block on which to mine the next block.
-// reserved_offset - unsigned int; Reserved offset.
+// reserved_offset - unsigned int; Offset of the reserved space for the extra nonce in the blob, 0 if none was requested.
+// seed_hash - string; RandomX seed hash of the block.
+// seed_height - unsigned int; Height of the block the seed hash belongs to.
+// next_seed_hash - string; RandomX seed hash of the next seed epoch, empty until it is known.
 // status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
 type BlockTemplate struct {
-	BlockTemplateBlob string `json:"blocktemplate_blob "`
-	Difficulty        uint   `json:"difficulty"`
-	Height            uint   `json:"height"`
-	PrevHash          string `json:"prev_hash"`
-	ReservedOffset    uint   `json:"reserved_offset"`
-	Status            string `json:"status"`
+	BlockTemplateBlob string     `json:"blocktemplate_blob"`
+	BlockHashingBlob  string     `json:"blockhashing_blob"`
+	Difficulty        uint64     `json:"difficulty"`
+	DifficultyTop64   uint64     `json:"difficulty_top64"`
+	WideDifficulty    Difficulty `json:"wide_difficulty"`
+	ExpectedReward    uint64     `json:"expected_reward"`
+	Height            uint       `json:"height"`
+	PrevHash          string     `json:"prev_hash"`
+	ReservedOffset    uint       `json:"reserved_offset"`
+	SeedHash          string     `json:"seed_hash"`
+	SeedHeight        uint64     `json:"seed_height"`
+	NextSeedHash      string     `json:"next_seed_hash"`
+	Status            string     `json:"status"`
+	Untrusted         bool       `json:"untrusted"`
 }
 
 // BlockHeader
+// block_size - unsigned int; Backward compatible name of block_weight.
+// block_weight - unsigned int; The weight of the block in bytes.
+// cumulative_difficulty - unsigned int; Least significant 64 bits of the cumulative difficulty of the chain up to this block.
+// cumulative_difficulty_top64 - unsigned int; Most significant 64 bits of the cumulative difficulty.
+// wide_cumulative_difficulty - string; The cumulative difficulty as a hex string.
 // depth - unsigned int; The number of blocks succeeding this block on the blockchain. A larger number means an older block.
-// difficulty - unsigned int; The strength of the Monero network based on mining power.
+// difficulty - unsigned int; The strength of the Monero network based on mining power, least significant 64 bits.
+// difficulty_top64 - unsigned int; Most significant 64 bits of the difficulty.
+// wide_difficulty - string; The difficulty as a hex string.
 // hash - string; The hash of this block.
 // height - unsigned int; The number of blocks preceding this block on the blockchain.
+// long_term_weight - unsigned int; The long term weight of the block, which limits how fast the median weight can grow.
 // major_version - unsigned int; The major version of the monero protocol at this block height.
+// miner_tx_hash - string; The hash of the block's miner transaction.
 // minor_version - unsigned int; The minor version of the monero protocol at this block height.
 // nonce - unsigned int; a cryptographic random one-time number used in mining a Monero block.
+// num_txes - unsigned int; Number of transactions in the block, not counting the miner transaction.
 // orphan_status - boolean; Usually false. If true, this block is not part of the longest chain.
+// pow_hash - string; The proof of work hash of the block, only filled in when asked for with fill_pow_hash.
 // prev_hash - string; The hash of the block immediately preceding this block in the chain.
 // reward - unsigned int; The amount of new atomic units generated in this block and rewarded to the miner. Note: 1 XMR = 1e12 atomic units.
 // timestamp - unsigned int; The time the block was recorded into the blockchain.
 type BlockHeader struct {
-	Depth        uint64 `json:"depth"`
-	Difficulty   uint   `json:"difficulty"`
-	Hash         string `json:"hash"`
-	Height       uint   `json:"height"`
-	MajorVersion uint   `json:"major_version"`
-	MinorVersion uint   `json:"minor_version"`
-	Nonce        uint   `json:"nonce"`
-	OrphanStatus bool   `json:"orphan_status"`
-	PrevHash     string `json:"prev_hash"`
-	Reward       uint   `json:"reward "`
-	Timestamp    uint   `json:"timestamp"`
+	BlockSize                 uint64     `json:"block_size"`
+	BlockWeight               uint64     `json:"block_weight"`
+	CumulativeDifficulty      uint64     `json:"cumulative_difficulty"`
+	CumulativeDifficultyTop64 uint64     `json:"cumulative_difficulty_top64"`
+	WideCumulativeDifficulty  Difficulty `json:"wide_cumulative_difficulty"`
+	Depth                     uint64     `json:"depth"`
+	Difficulty                uint64     `json:"difficulty"`
+	DifficultyTop64           uint64     `json:"difficulty_top64"`
+	WideDifficulty            Difficulty `json:"wide_difficulty"`
+	Hash                      string     `json:"hash"`
+	Height                    uint64     `json:"height"`
+	LongTermWeight            uint64     `json:"long_term_weight"`
+	MajorVersion              uint       `json:"major_version"`
+	MinerTxHash               string     `json:"miner_tx_hash"`
+	MinorVersion              uint       `json:"minor_version"`
+	Nonce                     uint       `json:"nonce"`
+	NumTxes                   uint64     `json:"num_txes"`
+	OrphanStatus              bool       `json:"orphan_status"`
+	PowHash                   string     `json:"pow_hash"`
+	PrevHash                  string     `json:"prev_hash"`
+	Reward                    uint64     `json:"reward"`
+	Timestamp                 uint64     `json:"timestamp"`
 }
 
 // BlockHeaderResponse
 // block_header - A structure containing block header information.
 // status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
 type BlockHeaderResponse struct {
 	BlockHeader BlockHeader `json:"block_header"`
 	Status      string      `json:"status"`
+	Untrusted   bool        `json:"untrusted"`
 }
 
 // Block
 // blob - string; Hexadecimal blob of block information.
 // block_header - A structure containing block header information. See getlastblockheader.
 // json - json string; JSON formatted block details:
+// miner_tx_hash - string; The hash of the block's miner transaction.
 // status - string; General RPC error code. "OK" means everything looks good.
+// tx_hashes - List of the hashes of the transactions in the block, not counting the miner transaction.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
 type Block struct {
 	Blob        string      `json:"blob"`
 	BlockHeader BlockHeader `json:"block_header"`
 	Json        string      `json:"json"`
-	Status      string      `status`
+	MinerTxHash string      `json:"miner_tx_hash"`
+	Status      string      `json:"status"`
+	TxHashes    []string    `json:"tx_hashes,omitempty"`
+	Untrusted   bool        `json:"untrusted"`
 }
 
 // BlockDetails
@@ -113,14 +160,14 @@ func (b *Block) ParseJSON() (BlockDetails, error) {
 // gen - Miner txs are coinbase txs, or "gen".
 // height - This block height, a.k.a. when the coinbase is generated.
 // vout - List of transaction outputs. Each output contains:
-// extra - Usually called the "transaction ID" but can be used to include any random 32 byte/64 character hex string.
+// extra - Extra data, e.g. the transaction public key and extra nonce, see TxExtra.Parse.
 // signatures - Contain signatures of tx signers. Coinbased txs do not have signatures.
 type MinerTransactionInfo struct {
 	Version            uint                 `json:"version"`
 	UnlockTime         int                  `json:"unlock_time"`
 	TransactionInputs  []TransactionInputs  `json:"vin"`
 	TransactionOutputs []TransactionOutputs `json:"vout"`
-	Extra              []string             `json:"extra"`
+	Extra              TxExtra              `json:"extra"`
 	Signatures         []string             `json:"signatures"`
 }
 
@@ -147,6 +194,7 @@ type TransactionOutputs struct {
 // current_download - unsigned int; Current bytes downloaded by node.
 // current_upload - unsigned int; Current bytes uploaded by node.
 // incoming - boolean; Is the node getting information from your node?
+// height - unsigned int; The height of the node's chain.
 // ip - string; The node's IP address.
 // live_time - unsigned int
 // local_ip - boolean
@@ -157,12 +205,13 @@ type TransactionOutputs struct {
 // recv_idle_time - unsigned int
 // send_count - unsigned int
 // send_idle_time - unsigned int
-//state - string
+// state - string
 type Connection struct {
 	AvgDownload     uint   `json:"avg_download"`
 	AvgUpload       uint   `json:"avg_upload"`
 	CurrentDownload uint   `json:"current_download"`
 	CurrentUpload   uint   `json:"current_upload"`
+	Height          uint   `json:"height"`
 	Incoming        bool   `json:"incoming"`
 	Ip              string `json:"ip"`
 	LiveTime        uint   `json:"live_time"`
@@ -185,35 +234,89 @@ type ConnectionResponse struct {
 }
 
 // Info
+// adjusted_time - unsigned int; Network time the daemon uses, its clock adjusted by the median time of its peers.
 // alt_blocks_count - unsigned int; Number of alternative blocks to main chain.
-// difficulty - unsigned int; Network difficulty (analogous to the strength of the network)
+// block_size_limit - unsigned int; Backward compatible name of block_weight_limit.
+// block_size_median - unsigned int; Backward compatible name of block_weight_median.
+// block_weight_limit - unsigned int; Maximum allowed block weight.
+// block_weight_median - unsigned int; Median block weight of the latest 100 blocks.
+// bootstrap_daemon_address - string; Bootstrap daemon the node relays requests to while syncing, if any.
+// busy_syncing - boolean; States if the daemon is busy downloading or processing blocks.
+// cumulative_difficulty - unsigned int; Least significant 64 bits of the cumulative difficulty of the chain.
+// cumulative_difficulty_top64 - unsigned int; Most significant 64 bits of the cumulative difficulty.
+// wide_cumulative_difficulty - string; The cumulative difficulty as a hex string.
+// database_size - unsigned int; Size of the blockchain database in bytes, rounded up to 5 GB in restricted mode.
+// difficulty - unsigned int; Network difficulty (analogous to the strength of the network), least significant 64 bits.
+// difficulty_top64 - unsigned int; Most significant 64 bits of the difficulty.
+// wide_difficulty - string; The difficulty as a hex string.
+// free_space - unsigned int; Free disk space for the database in bytes, the maximum value in restricted mode.
 // grey_peerlist_size - unsigned int; Grey Peerlist Size
 // height - unsigned int; Current length of longest chain known to daemon.
+// height_without_bootstrap - unsigned int; Height of the daemon's own chain, without the bootstrap daemon's.
 // incoming_connections_count - unsigned int; Number of peers connected to and pulling from your node.
+// mainnet - boolean; States if the node is on mainnet.
+// nettype - string; Network type, see NetworkType.
+// offline - boolean; States if the node is offline, not connecting to peers.
 // outgoing_connections_count - unsigned int; Number of peers that you are connected to and getting information from.
+// restricted - boolean; States if the RPC server is in restricted mode.
+// rpc_connections_count - unsigned int; Number of RPC clients connected to the daemon, including this one.
+// stagenet - boolean; States if the node is on stagenet.
+// start_time - unsigned int; Start time of the daemon as a unix timestamp, 0 in restricted mode.
 // status - string; General RPC error code. "OK" means everything looks good.
+// synchronized - boolean; States if the daemon considers itself synchronized with the network.
 // target - unsigned int; Current target for next proof of work.
 // target_height - unsigned int; The height of the next block in the chain.
 // testnet - boolean; States if the node is on the testnet (true) or mainnet (false).
 // top_block_hash - string; Hash of the highest block in the chain.
 // tx_count - unsigned int; Total number of non-coinbase transaction in the chain.
-// tx_pool_siz - unsigned int; Number of transactions that have been broadcast but not included in a block.
+// tx_pool_size - unsigned int; Number of transactions that have been broadcast but not included in a block.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+// update_available - boolean; States if a newer daemon version is available.
+// version - string; Version of the daemon, empty in restricted mode.
+// was_bootstrap_ever_used - boolean; States if the daemon ever used a bootstrap daemon.
 // white_peerlist_size - unsigned int; White Peerlist Size
 type Info struct {
-	AltBlocksCount           uint   `json:"alt_blocks_count"`
-	Difficulty               uint   `json:"difficulty"`
-	GreyPeerlistSize         uint   `json:"grey_peerlist_size"`
-	Height                   uint   `json:"height"`
-	IncomingConnectionsCount uint   `json:"incoming_connections_count"`
-	OutgoingConnectionsCount uint   `json:"outgoing_connections_count"`
-	Status                   string `json:"status"`
-	Target                   uint   `json:"target"`
-	TargetHeight             uint   `json:"target_height"`
-	Testnet                  bool   `json:"testnet"`
-	TopBlockHash             string `json:"top_block_hash"`
-	TxCount                  uint   `json:"tx_count"`
-	TxPoolSiz                uint   `json:"tx_pool_siz"`
-	WhitePeerlistSize        uint   `json:"white_peerlist_size"`
+	AdjustedTime              uint64      `json:"adjusted_time"`
+	AltBlocksCount            uint        `json:"alt_blocks_count"`
+	BlockSizeLimit            uint64      `json:"block_size_limit"`
+	BlockSizeMedian           uint64      `json:"block_size_median"`
+	BlockWeightLimit          uint64      `json:"block_weight_limit"`
+	BlockWeightMedian         uint64      `json:"block_weight_median"`
+	BootstrapDaemonAddress    string      `json:"bootstrap_daemon_address"`
+	BusySyncing               bool        `json:"busy_syncing"`
+	CumulativeDifficulty      uint64      `json:"cumulative_difficulty"`
+	CumulativeDifficultyTop64 uint64      `json:"cumulative_difficulty_top64"`
+	WideCumulativeDifficulty  Difficulty  `json:"wide_cumulative_difficulty"`
+	DatabaseSize              uint64      `json:"database_size"`
+	Difficulty                uint64      `json:"difficulty"`
+	DifficultyTop64           uint64      `json:"difficulty_top64"`
+	WideDifficulty            Difficulty  `json:"wide_difficulty"`
+	FreeSpace                 uint64      `json:"free_space"`
+	GreyPeerlistSize          uint        `json:"grey_peerlist_size"`
+	Height                    uint        `json:"height"`
+	HeightWithoutBootstrap    uint64      `json:"height_without_bootstrap"`
+	IncomingConnectionsCount  uint        `json:"incoming_connections_count"`
+	Mainnet                   bool        `json:"mainnet"`
+	NetType                   NetworkType `json:"nettype"`
+	Offline                   bool        `json:"offline"`
+	OutgoingConnectionsCount  uint        `json:"outgoing_connections_count"`
+	Restricted                bool        `json:"restricted"`
+	RpcConnectionsCount       uint64      `json:"rpc_connections_count"`
+	Stagenet                  bool        `json:"stagenet"`
+	StartTime                 uint64      `json:"start_time"`
+	Status                    string      `json:"status"`
+	Synchronized              bool        `json:"synchronized"`
+	Target                    uint        `json:"target"`
+	TargetHeight              uint        `json:"target_height"`
+	Testnet                   bool        `json:"testnet"`
+	TopBlockHash              string      `json:"top_block_hash"`
+	TxCount                   uint        `json:"tx_count"`
+	TxPoolSiz                 uint        `json:"tx_pool_size"`
+	Untrusted                 bool        `json:"untrusted"`
+	UpdateAvailable           bool        `json:"update_available"`
+	Version                   string      `json:"version"`
+	WasBootstrapEverUsed      bool        `json:"was_bootstrap_ever_used"`
+	WhitePeerlistSize         uint        `json:"white_peerlist_size"`
 }
 
 // HardForkInfo
@@ -239,13 +342,15 @@ type HardForkInfo struct {
 }
 
 // Ban
+// host - string; Host to ban, an IP address, an IPv4 subnet in CIDR notation or an onion or i2p address. Takes precedence over ip.
 // ip - unsigned int; IP address to ban, in Int format.
-// ban - boolean; Set true to ban.
+// ban - boolean; Set true to ban, false to unban.
 // seconds - unsigned int; Number of seconds to ban node.
 type Ban struct {
-	Ip      uint `json:"ip"`
-	Ban     bool `json:"ban, omitempty"`
-	Seconds uint `json:"seconds"`
+	Host    string `json:"host,omitempty"`
+	Ip      uint   `json:"ip,omitempty"`
+	Ban     bool   `json:"ban,omitempty"`
+	Seconds uint   `json:"seconds"`
 }
 
 // Creates new ban
@@ -257,6 +362,29 @@ func NewBanRequest(ip uint, ban bool, seconds uint) Ban {
 	}
 }
 
+// NewHostBan creates a ban of a host, an IP address or an IPv4 subnet such as
+// "192.0.2.0/24".
+func NewHostBan(host string, seconds uint) Ban {
+	return Ban{
+		Host:    host,
+		Ban:     true,
+		Seconds: seconds,
+	}
+}
+
+// NewUnban creates a request lifting the ban of a host.
+func NewUnban(host string) Ban {
+	return Ban{Host: host}
+}
+
+// Address returns the banned host, or the IP address if no host is set.
+func (b Ban) Address() string {
+	if b.Host != "" {
+		return b.Host
+	}
+	return net.IPv4(byte(b.Ip), byte(b.Ip>>8), byte(b.Ip>>16), byte(b.Ip>>24)).String()
+}
+
 // BanResponse
 // bans - A list of nodes
 // status - string; General RPC error code. "OK" means everything looks good.
@@ -0,0 +1,36 @@
+package monero
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func FuzzBlockParseJSON(f *testing.F) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", "daemon", "getblock.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	var rep struct {
+		Result Block `json:"result"`
+	}
+	if err := json.Unmarshal(data, &rep); err != nil {
+		f.Fatal(err)
+	}
+	f.Add(rep.Result.Json)
+	f.Add(`{"major_version":1,"minor_version":0,"timestamp":0,"prev_id":"0000000000000000000000000000000000000000000000000000000000000000","nonce":10000,"miner_tx":{"version":1,"unlock_time":60,"vin":[{"gen":{"height":0}}],"vout":[{"amount":17592186044415,"target":{"key":"9b2e4c0281c0b02e7c53291a94d1d0cbff8883f8024f5142ee494ffbbd088071"}}],"extra":[1,119,103,170,252,222,155,224,13,207,208,152,113,94,188,247,244,16,218,235,197,130,253,166,157,36,162,142,157,11,200,144,209],"signatures":[]},"tx_hashes":[]}`)
+	f.Add(`{"miner_tx":{"vin":[{"gen":{"height":-1}}],"vout":[{"amount":1e30}]}}`)
+	f.Add(`[]`)
+	f.Add(``)
+	f.Fuzz(func(t *testing.T, s string) {
+		b := Block{Json: s}
+		details, err := b.ParseJSON()
+		if err != nil {
+			return
+		}
+		if _, err := json.Marshal(details); err != nil {
+			t.Fatalf("cannot re-encode parsed block: %v", err)
+		}
+	})
+}
@@ -32,3 +32,16 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// statusError returns an error if the status of an "other" daemon RPC call
+// reports a failure. These methods answer failures with HTTP 200 and a status
+// such as "Failed" instead of a JSON-RPC error.
+func statusError(method, status string) error {
+	if status == "" || status == "OK" {
+		return nil
+	}
+	return &Error{
+		Code:    E_SERVER,
+		Message: method + ": " + status,
+	}
+}
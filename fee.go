@@ -0,0 +1,105 @@
+package monero
+
+// Transfer priorities, as used in the priority field of the wallet's transfer
+// requests.
+const (
+	PriorityDefault     = 0
+	PriorityUnimportant = 1
+	PriorityNormal      = 2
+	PriorityElevated    = 3
+	PriorityPriority    = 4
+)
+
+// feeMultipliers are the multipliers wallet2 applies to the base fee for
+// priorities 1 to 4 when the daemon does not send per priority fees.
+var feeMultipliers = [...]uint64{1, 5, 25, 1000}
+
+// FeeEstimate
+// fee - unsigned int; Base fee per byte of weight, in atomic units.
+// fees - List of fees per byte of weight for priorities 1 to 4, since hard fork 15.
+// quantization_mask - unsigned int; Fees are rounded up to a multiple of this.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type FeeEstimate struct {
+	Fee              uint64   `json:"fee"`
+	Fees             []uint64 `json:"fees,omitempty"`
+	QuantizationMask uint64   `json:"quantization_mask"`
+	Status           string   `json:"status"`
+	Untrusted        bool     `json:"untrusted"`
+}
+
+// FeePerByte returns the fee per byte of weight for a priority.
+// PriorityDefault is treated as PriorityNormal, which is what wallet2 uses
+// unless its auto-low-priority check finds the pool nearly empty and drops to
+// PriorityUnimportant; that check is not made here. Priorities above
+// PriorityPriority are treated as PriorityPriority.
+func (f FeeEstimate) FeePerByte(priority uint32) uint64 {
+	if priority == PriorityDefault {
+		priority = PriorityNormal
+	} else if priority > PriorityPriority {
+		priority = PriorityPriority
+	}
+	if int(priority) <= len(f.Fees) {
+		return f.Fees[priority-1]
+	}
+	return f.Fee * feeMultipliers[priority-1]
+}
+
+// CalculateFee returns the fee wallet2 would pay for a transaction of the
+// given weight at priority, rounded up to the quantization mask.
+func (f FeeEstimate) CalculateFee(weight uint64, priority uint32) uint64 {
+	fee := weight * f.FeePerByte(priority)
+	if f.QuantizationMask > 1 {
+		fee = (fee + f.QuantizationMask - 1) / f.QuantizationMask * f.QuantizationMask
+	}
+	return fee
+}
+
+// EstimateTxWeight estimates the weight of a CLSAG, Bulletproof+ transaction
+// with view tags the way wallet2 does. extraSize is the size of tx_extra, 44
+// for a transaction public key and an encrypted payment id.
+func EstimateTxWeight(inputs, outputs, ringSize, extraSize int) uint64 {
+	n, m, ring := uint64(inputs), uint64(outputs), uint64(ringSize)
+	logPadded := uint64(0)
+	for 1<<logPadded < m {
+		logPadded++
+	}
+
+	// Prefix: version and unlock time, inputs with their key offsets and key
+	// image, outputs and extra.
+	size := 1 + 6 + n*(1+6+ring*2+32) + m*(6+32) + uint64(extraSize)
+	// RingCT type, Bulletproof+, CLSAGs and view tags.
+	size += 1 + (2*(6+logPadded)+6)*32 + 3 + n*(32*ring+64) + m
+	// Pseudo outputs, ecdhInfo, output commitments and fee.
+	size += 32*n + 8*m + 32*m + 4
+
+	// Range proofs of many outputs are aggregated into one that is much
+	// smaller than it is expensive to verify, the weight claws part of the
+	// difference back.
+	if m > 2 {
+		const bpBase = 32 * (6 + 7*2) / 2
+		logPadded := uint64(2)
+		for 1<<logPadded < m {
+			logPadded++
+		}
+		bpSize := 32 * (6 + 2*(6+logPadded))
+		size += (bpBase*(1<<logPadded) - bpSize) * 4 / 5
+	}
+	return size
+}
+
+// GetFeeEstimate returns the fee per byte of weight the daemon suggests.
+// graceBlocks makes the estimate valid for that many blocks ahead.
+func (c *DaemonClient) GetFeeEstimate(graceBlocks uint64) (FeeEstimate, error) {
+	var fe FeeEstimate
+	request := struct {
+		GraceBlocks uint64 `json:"grace_blocks"`
+	}{graceBlocks}
+	if err := c.Daemon("get_fee_estimate", &request, &fe); err != nil {
+		return fe, err
+	}
+	if err := statusError("get_fee_estimate", fe.Status); err != nil {
+		return fe, err
+	}
+	return fe, nil
+}
@@ -0,0 +1,71 @@
+package monero
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCalculateFee(t *testing.T) {
+	f := FeeEstimate{Fee: 20000, Fees: []uint64{20000, 80000, 320000, 4000000}, QuantizationMask: 10000}
+	tests := []struct {
+		weight   uint64
+		priority uint32
+		want     uint64
+	}{
+		{1536, PriorityDefault, 122880000},
+		{1536, PriorityUnimportant, 30720000},
+		{1536, PriorityNormal, 122880000},
+		{1536, PriorityElevated, 491520000},
+		{1536, PriorityPriority, 6144000000},
+		{1536, 9, 6144000000},
+		{1, PriorityUnimportant, 20000},
+	}
+	for _, tc := range tests {
+		if got := f.CalculateFee(tc.weight, tc.priority); got != tc.want {
+			t.Errorf("CalculateFee(%d, %d) = %d, want %d", tc.weight, tc.priority, got, tc.want)
+		}
+	}
+
+	// Rounded up to the quantization mask.
+	f.Fees[0] = 20001
+	if got, want := f.CalculateFee(1536, PriorityUnimportant), uint64(30730000); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+
+	// Without per priority fees the base fee is multiplied.
+	old := FeeEstimate{Fee: 20000, QuantizationMask: 10000}
+	if got, want := old.CalculateFee(1000, PriorityElevated), uint64(500000000); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestEstimateTxWeight(t *testing.T) {
+	tests := []struct {
+		inputs, outputs int
+		want            uint64
+	}{
+		{1, 2, 1536},
+		{2, 2, 2215},
+		{1, 3, 2139},
+	}
+	for _, tc := range tests {
+		if got := EstimateTxWeight(tc.inputs, tc.outputs, 16, 44); got != tc.want {
+			t.Errorf("EstimateTxWeight(%d, %d) = %d, want %d", tc.inputs, tc.outputs, got, tc.want)
+		}
+	}
+}
+
+func TestGetFeeEstimateStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"0","jsonrpc":"2.0","result":{"fee":0,"status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	_, err := c.GetFeeEstimate(10)
+	if rpcErr, ok := err.(*Error); !ok || rpcErr.Code != E_SERVER {
+		t.Fatalf("got %v, want a server error", err)
+	}
+}
@@ -0,0 +1,189 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// loadFixture decodes the result of a fixture into v. Fixtures of the
+// daemon's "other" methods have no envelope and are decoded as they are.
+func loadFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Result != nil {
+		data = envelope.Result
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+}
+
+// blockHash returns the id of a serialized block and of its miner transaction.
+func blockHash(t *testing.T, blobHex string) (string, string) {
+	t.Helper()
+	blob, err := hex.DecodeString(blobHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := parseTemplate(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hb := l.hashingBlob(blob)
+	size := make([]byte, binary.MaxVarintLen64)
+	size = size[:binary.PutUvarint(size, uint64(len(hb)))]
+	id := keccak256(size, hb)
+	miner := l.minerTxID(blob)
+	return hex.EncodeToString(id[:]), hex.EncodeToString(miner[:])
+}
+
+// TestDaemonFixturesAgree checks that the daemon fixtures describe a single
+// chain, so that tests combining several of them stay meaningful.
+func TestDaemonFixturesAgree(t *testing.T) {
+	var headers struct {
+		Headers []BlockHeader `json:"headers"`
+	}
+	loadFixture(t, "daemon/getblockheadersrange", &headers)
+	if len(headers.Headers) == 0 {
+		t.Fatal("no headers")
+	}
+	for i, h := range headers.Headers {
+		if h.WideDifficulty.Cmp(h.Difficulty128()) != 0 || h.Difficulty128().Cmp(NewDifficulty(h.DifficultyTop64, h.Difficulty)) != 0 {
+			t.Errorf("block %d: wide difficulty %s disagrees with %d", h.Height, h.WideDifficulty, h.Difficulty)
+		}
+		if h.WideCumulativeDifficulty.Cmp(NewDifficulty(h.CumulativeDifficultyTop64, h.CumulativeDifficulty)) != 0 {
+			t.Errorf("block %d: wide cumulative difficulty %s disagrees with %d", h.Height, h.WideCumulativeDifficulty, h.CumulativeDifficulty)
+		}
+		if i == 0 {
+			continue
+		}
+		prev := headers.Headers[i-1]
+		if h.Height != prev.Height+1 || h.PrevHash != prev.Hash {
+			t.Errorf("block %d does not follow block %d", h.Height, prev.Height)
+		}
+		if h.CumulativeDifficulty128().Sub(prev.CumulativeDifficulty128()).Cmp(h.Difficulty128()) != 0 {
+			t.Errorf("block %d: cumulative difficulty does not grow by its difficulty", h.Height)
+		}
+	}
+	tip := headers.Headers[len(headers.Headers)-1]
+
+	var last BlockHeaderResponse
+	loadFixture(t, "daemon/getlastblockheader", &last)
+	if last.BlockHeader != tip {
+		t.Errorf("last block header %s is not the tip %s", last.BlockHeader.Hash, tip.Hash)
+	}
+	var byHash BlockHeaderResponse
+	loadFixture(t, "daemon/getblockheaderbyhash", &byHash)
+	if byHash.BlockHeader != tip {
+		t.Errorf("header by hash %s is not the tip %s", byHash.BlockHeader.Hash, tip.Hash)
+	}
+
+	var block Block
+	loadFixture(t, "daemon/getblock", &block)
+	if block.BlockHeader != tip {
+		t.Errorf("getblock header %s is not the tip %s", block.BlockHeader.Hash, tip.Hash)
+	}
+	id, miner := blockHash(t, block.Blob)
+	if id != tip.Hash {
+		t.Errorf("getblock blob hashes to %s, want %s", id, tip.Hash)
+	}
+	if miner != block.MinerTxHash || miner != tip.MinerTxHash {
+		t.Errorf("miner transaction of the blob is %s, want %s", miner, block.MinerTxHash)
+	}
+	if uint64(len(block.TxHashes)) != tip.NumTxes {
+		t.Errorf("getblock has %d transactions, header says %d", len(block.TxHashes), tip.NumTxes)
+	}
+
+	var info Info
+	loadFixture(t, "daemon/get_info", &info)
+	if uint64(info.Height) != tip.Height+1 || info.TopBlockHash != tip.Hash {
+		t.Errorf("get_info height %d and top %s disagree with the tip", info.Height, info.TopBlockHash)
+	}
+	if info.CumulativeDifficulty128().Cmp(tip.CumulativeDifficulty128()) != 0 {
+		t.Errorf("get_info cumulative difficulty %s, tip has %s", info.CumulativeDifficulty128(), tip.CumulativeDifficulty128())
+	}
+
+	var data MinerData
+	loadFixture(t, "daemon/get_miner_data", &data)
+	bt := loadBlockTemplate(t)
+	if data.PrevID != tip.Hash || bt.PrevHash != tip.Hash {
+		t.Errorf("miner data and template do not build on the tip")
+	}
+	if data.Height != uint64(info.Height) || bt.Height != info.Height {
+		t.Errorf("miner data height %d and template height %d, want %d", data.Height, bt.Height, info.Height)
+	}
+	if data.SeedHash != bt.SeedHash {
+		t.Errorf("miner data seed %s, template seed %s", data.SeedHash, bt.SeedHash)
+	}
+	if _, err := bt.HashingBlob(); err != nil {
+		t.Errorf("template blob: %v", err)
+	}
+
+	var txs TransactionsResponse
+	loadFixture(t, "daemon/get_transactions", &txs)
+	for _, e := range txs.Txs {
+		if e.AsHex == "" {
+			continue
+		}
+		tx, err := e.Decode()
+		if err != nil {
+			t.Errorf("transaction %s: %v", e.TxHash, err)
+			continue
+		}
+		if id, err := tx.ID(); err != nil || id != e.TxHash {
+			t.Errorf("transaction %s has id %s (%v)", e.TxHash, id, err)
+		}
+	}
+}
+
+// TestWalletFixturesAgree checks that the transactions the wallet fixtures
+// return decode to the ids and fees reported next to them.
+func TestWalletFixturesAgree(t *testing.T) {
+	var split []TransferSplit
+	for _, name := range []string{"transfer", "sweep_single"} {
+		var tr Transfer
+		loadFixture(t, "wallet/"+name, &tr)
+		split = append(split, TransferSplit{
+			TxHashList: []string{tr.TxHash},
+			FeeList:    []uint64{tr.Fee},
+			TxBlobList: []string{tr.TxBlob},
+		})
+	}
+	for _, name := range []string{"transfer_split", "sweep_all", "sweep_dust"} {
+		var ts TransferSplit
+		loadFixture(t, "wallet/"+name, &ts)
+		split = append(split, ts)
+	}
+	for _, ts := range split {
+		if len(ts.TxBlobList) != len(ts.TxHashList) || len(ts.FeeList) != len(ts.TxHashList) {
+			t.Errorf("%d blobs and %d fees for %d transactions", len(ts.TxBlobList), len(ts.FeeList), len(ts.TxHashList))
+			continue
+		}
+		for i, blob := range ts.TxBlobList {
+			tx, err := (TransactionEntry{AsHex: blob}).Decode()
+			if err != nil {
+				t.Errorf("transaction %s: %v", ts.TxHashList[i], err)
+				continue
+			}
+			if id, err := tx.ID(); err != nil || id != ts.TxHashList[i] {
+				t.Errorf("transaction %s has id %s (%v)", ts.TxHashList[i], id, err)
+			}
+			if tx.RctSignatures == nil || tx.RctSignatures.TxnFee != ts.FeeList[i] {
+				t.Errorf("transaction %s does not pay a fee of %d", ts.TxHashList[i], ts.FeeList[i])
+			}
+		}
+	}
+}
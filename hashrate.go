@@ -0,0 +1,69 @@
+package monero
+
+import (
+	"fmt"
+	"time"
+)
+
+// DifficultyTarget is the average time between blocks the difficulty aims
+// for.
+const DifficultyTarget = 120 * time.Second
+
+// Hashrate returns the hashrate, in hashes per second, at which blocks of
+// difficulty d are found every target on average.
+func (d Difficulty) Hashrate(target time.Duration) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return d.Float64() / target.Seconds()
+}
+
+// Hashrate estimates the network hashrate from the difficulty of the next
+// block and the daemon's block target.
+func (i Info) Hashrate() float64 {
+	target := DifficultyTarget
+	if i.Target > 0 {
+		target = time.Duration(i.Target) * time.Second
+	}
+	return i.Difficulty128().Hashrate(target)
+}
+
+// HeadersHashrate returns the hashrate, in hashes per second, averaged over
+// consecutive headers sorted by height: the work of the blocks after the
+// first divided by the time between the first and the last.
+func HeadersHashrate(headers []BlockHeader) (float64, error) {
+	if len(headers) < 2 {
+		return 0, fmt.Errorf("need at least 2 headers, got %d", len(headers))
+	}
+	var work Difficulty
+	for i := 1; i < len(headers); i++ {
+		if headers[i].Height != headers[i-1].Height+1 {
+			return 0, fmt.Errorf("header at height %d does not follow height %d", headers[i].Height, headers[i-1].Height)
+		}
+		work = work.Add(headers[i].Difficulty128())
+	}
+	first, last := headers[0].Timestamp, headers[len(headers)-1].Timestamp
+	if last <= first {
+		return 0, fmt.Errorf("headers %d-%d span no time", headers[0].Height, headers[len(headers)-1].Height)
+	}
+	return work.Float64() / float64(last-first), nil
+}
+
+// AverageHashrate returns the network hashrate averaged over the last blocks
+// blocks of the chain.
+func (c *DaemonClient) AverageHashrate(blocks uint64) (float64, error) {
+	top, err := c.GetLastBlockHeader()
+	if err != nil {
+		return 0, err
+	}
+	end := top.BlockHeader.Height
+	start := uint64(0)
+	if end > blocks {
+		start = end - blocks
+	}
+	headers, err := c.GetBlockHeadersRange(start, end)
+	if err != nil {
+		return 0, err
+	}
+	return HeadersHashrate(headers)
+}
@@ -0,0 +1,56 @@
+package monero
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHashrate(t *testing.T) {
+	d := NewDifficulty(0, 240000)
+	if got := d.Hashrate(DifficultyTarget); got != 2000 {
+		t.Errorf("got %v H/s", got)
+	}
+	if got := d.Hashrate(0); got != 0 {
+		t.Errorf("got %v H/s for no target", got)
+	}
+	if got := (Info{Difficulty: 240000, Target: 60}).Hashrate(); got != 4000 {
+		t.Errorf("got %v H/s for a 60s target", got)
+	}
+
+	headers := []BlockHeader{
+		{Height: 10, Timestamp: 1000, Difficulty: 1 << 40},
+		{Height: 11, Timestamp: 1100, Difficulty: 1 << 40},
+		{Height: 12, Timestamp: 1300, WideDifficulty: NewDifficulty(1, 0)},
+	}
+	want := (float64(1<<40) + math.Pow(2, 64)) / 300
+	if got, err := HeadersHashrate(headers); err != nil || got != want {
+		t.Errorf("got %v, %v, want %v", got, err, want)
+	}
+	for name, hs := range map[string][]BlockHeader{
+		"one header": headers[:1],
+		"gap":        {headers[0], headers[2]},
+		"no time":    {{Height: 1, Timestamp: 5}, {Height: 2, Timestamp: 5}},
+	} {
+		if _, err := HeadersHashrate(hs); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestAverageHashrate(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	got, err := c.AverageHashrate(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two blocks of difficulty 227178885765 in 233 seconds.
+	if want := 2 * 227178885765 / float64(233); got != want {
+		t.Errorf("got %v H/s, want %v", got, want)
+	}
+	if _, params := srv.lastRequest(); string(params) != `{"start_height":2286452,"end_height":2286454}` {
+		t.Errorf("requested %s", params)
+	}
+}
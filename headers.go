@@ -0,0 +1,237 @@
+package monero
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// HeaderRangeLimit is the maximum number of headers a daemon in restricted
+// RPC mode returns for one getblockheadersrange call.
+const HeaderRangeLimit = 1000
+
+// GetBlockHeadersRange returns the headers of the blocks from start to end,
+// both inclusive. Large ranges are split into several calls so they stay
+// within the limits of restricted RPC daemons.
+func (c *DaemonClient) GetBlockHeadersRange(start, end uint64) ([]BlockHeader, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid header range %d-%d", start, end)
+	}
+	size := end - start + 1
+	if size == 0 || size > HeaderRangeLimit {
+		size = HeaderRangeLimit
+	}
+	headers := make([]BlockHeader, 0, size)
+	for from := start; from <= end; from += HeaderRangeLimit {
+		to := from + HeaderRangeLimit - 1
+		if to > end || to < from {
+			to = end
+		}
+		chunk, err := c.getBlockHeadersRange(from, to)
+		if err != nil {
+			return headers, err
+		}
+		headers = append(headers, chunk...)
+		if to == end {
+			break
+		}
+	}
+	return headers, nil
+}
+
+// getBlockHeadersRange calls getblockheadersrange once and checks that the
+// daemon returned every header that was asked for.
+func (c *DaemonClient) getBlockHeadersRange(start, end uint64) ([]BlockHeader, error) {
+	var response struct {
+		Headers []BlockHeader `json:"headers"`
+		Status  string        `json:"status"`
+	}
+	request := struct {
+		StartHeight uint64 `json:"start_height"`
+		EndHeight   uint64 `json:"end_height"`
+	}{start, end}
+	if err := c.Daemon("getblockheadersrange", &request, &response); err != nil {
+		return nil, err
+	}
+	if uint64(len(response.Headers)) != end-start+1 {
+		return nil, fmt.Errorf("getblockheadersrange: got %d headers for range %d-%d", len(response.Headers), start, end)
+	}
+	for i, h := range response.Headers {
+		if h.Height != start+uint64(i) {
+			return nil, fmt.Errorf("getblockheadersrange: got header %d at position %d of range %d-%d", h.Height, i, start, end)
+		}
+	}
+	return response.Headers, nil
+}
+
+// ErrIteratorClosed is returned by HeaderIterator.Err if the iterator was
+// closed before it reached the end of its range.
+var ErrIteratorClosed = errors.New("iterator closed")
+
+// HeaderIterator walks the block headers of a height range. Chunks of the
+// range are fetched concurrently by a bounded number of workers, headers are
+// yielded in height order. Use it like a bufio.Scanner:
+//
+//	it := daemon.IterateBlockHeaders(start, end, 4)
+//	defer it.Close()
+//	for it.Next() {
+//		header := it.Header()
+//	}
+//	if err := it.Err(); err != nil {
+//		// Continue later with daemon.IterateBlockHeaders(it.ResumeHeight(), end, 4).
+//	}
+type HeaderIterator struct {
+	end    uint64
+	height uint64
+
+	results chan headerChunk
+	window  chan struct{}
+	done    chan struct{}
+	once    sync.Once
+
+	pending map[uint64]headerChunk
+	buf     []BlockHeader
+	header  BlockHeader
+	err     error
+}
+
+// headerChunk is the result of fetching the headers from start to end.
+type headerChunk struct {
+	start   uint64
+	end     uint64
+	headers []BlockHeader
+	err     error
+}
+
+// IterateBlockHeaders returns an iterator over the headers from start to end,
+// both inclusive, fetched by up to workers concurrent calls.
+func (c *DaemonClient) IterateBlockHeaders(start, end uint64, workers int) *HeaderIterator {
+	return c.iterateBlockHeaders(start, end, workers, HeaderRangeLimit)
+}
+
+func (c *DaemonClient) iterateBlockHeaders(start, end uint64, workers int, chunkSize uint64) *HeaderIterator {
+	if workers < 1 {
+		workers = 1
+	}
+	// At most two chunks per worker are fetched ahead of the consumer, which
+	// bounds the memory used to reorder them.
+	inFlight := 2 * workers
+	it := &HeaderIterator{
+		end:     end,
+		height:  start,
+		results: make(chan headerChunk, inFlight),
+		window:  make(chan struct{}, inFlight),
+		done:    make(chan struct{}),
+		pending: map[uint64]headerChunk{},
+	}
+	if start > end {
+		it.Close()
+		it.err = fmt.Errorf("invalid header range %d-%d", start, end)
+		return it
+	}
+
+	jobs := make(chan headerChunk)
+	go func() {
+		defer close(jobs)
+		for from := start; from <= end; from += chunkSize {
+			to := from + chunkSize - 1
+			if to > end || to < from {
+				to = end
+			}
+			select {
+			case it.window <- struct{}{}:
+			case <-it.done:
+				return
+			}
+			select {
+			case jobs <- headerChunk{start: from, end: to}:
+			case <-it.done:
+				return
+			}
+			if to == end {
+				return
+			}
+		}
+	}()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.headers, job.err = c.getBlockHeadersRange(job.start, job.end)
+				select {
+				case it.results <- job:
+				case <-it.done:
+					return
+				}
+			}
+		}()
+	}
+	return it
+}
+
+// Next advances to the next header, which is then available through Header.
+// It returns false at the end of the range or on an error.
+func (it *HeaderIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	select {
+	case <-it.done:
+		if it.height <= it.end {
+			it.err = ErrIteratorClosed
+		}
+		return false
+	default:
+	}
+	for len(it.buf) == 0 {
+		if it.height > it.end {
+			it.Close()
+			return false
+		}
+		chunk, ok := it.pending[it.height]
+		for !ok {
+			select {
+			case r := <-it.results:
+				it.pending[r.start] = r
+				chunk, ok = it.pending[it.height]
+			case <-it.done:
+				it.err = ErrIteratorClosed
+				return false
+			}
+		}
+		delete(it.pending, it.height)
+		<-it.window
+		if chunk.err != nil {
+			it.err = chunk.err
+			it.Close()
+			return false
+		}
+		it.buf = chunk.headers
+	}
+	it.header, it.buf = it.buf[0], it.buf[1:]
+	it.height++
+	return true
+}
+
+// Header returns the header Next advanced to.
+func (it *HeaderIterator) Header() BlockHeader {
+	return it.header
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *HeaderIterator) Err() error {
+	return it.err
+}
+
+// ResumeHeight returns the height of the header Next yields next. After an
+// error a new iterator starting at this height continues where this one
+// stopped.
+func (it *HeaderIterator) ResumeHeight() uint64 {
+	return it.height
+}
+
+// Close stops the workers. It is safe to call more than once.
+func (it *HeaderIterator) Close() {
+	it.once.Do(func() {
+		close(it.done)
+	})
+}
@@ -0,0 +1,202 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// headerRangeServer answers getblockheadersrange for any range of a chain of
+// the given height, after a random delay to shuffle the order responses
+// arrive in. Requests including failHeight fail with an RPC error.
+type headerRangeServer struct {
+	*httptest.Server
+
+	height     uint64
+	failHeight uint64
+
+	mu       sync.Mutex
+	calls    int
+	maxRange uint64
+	active   int32
+	peak     int32
+}
+
+func newHeaderRangeServer(t *testing.T, height uint64) *headerRangeServer {
+	s := &headerRangeServer{height: height}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		active := atomic.AddInt32(&s.active, 1)
+		defer atomic.AddInt32(&s.active, -1)
+
+		var req struct {
+			Params struct {
+				StartHeight uint64 `json:"start_height"`
+				EndHeight   uint64 `json:"end_height"`
+			} `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+			return
+		}
+		start, end := req.Params.StartHeight, req.Params.EndHeight
+
+		s.mu.Lock()
+		s.calls++
+		if end-start+1 > s.maxRange {
+			s.maxRange = end - start + 1
+		}
+		if active > s.peak {
+			s.peak = active
+		}
+		fail := s.failHeight != 0 && start <= s.failHeight && s.failHeight <= end
+		s.mu.Unlock()
+
+		time.Sleep(time.Duration(rand.Intn(3)) * time.Millisecond)
+		if fail || end >= s.height {
+			fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","error":{"code":-1,"message":"Invalid start/end heights."}}`)
+			return
+		}
+		var headers []BlockHeader
+		for h := start; h <= end; h++ {
+			headers = append(headers, BlockHeader{Height: h, Hash: fmt.Sprintf("%064x", h)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":      "0",
+			"jsonrpc": "2.0",
+			"result":  map[string]interface{}{"headers": headers, "status": "OK"},
+		})
+	}))
+	return s
+}
+
+func TestGetBlockHeadersRangeChunks(t *testing.T) {
+	srv := newHeaderRangeServer(t, 5000)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	headers, err := c.GetBlockHeadersRange(10, 2510)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2501 {
+		t.Fatalf("got %d headers, want 2501", len(headers))
+	}
+	for i, h := range headers {
+		if h.Height != uint64(10+i) {
+			t.Fatalf("header %d has height %d", i, h.Height)
+		}
+	}
+	if srv.calls != 3 || srv.maxRange != HeaderRangeLimit {
+		t.Errorf("got %d calls of at most %d headers, want 3 of %d", srv.calls, srv.maxRange, HeaderRangeLimit)
+	}
+
+	if _, err := c.GetBlockHeadersRange(20, 10); err == nil {
+		t.Error("expected an error for an inverted range")
+	}
+}
+
+func TestGetBlockHeadersRangeHuge(t *testing.T) {
+	srv := newHeaderRangeServer(t, 2500)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	for _, end := range []uint64{1 << 60, ^uint64(0)} {
+		headers, err := c.GetBlockHeadersRange(0, end)
+		if err == nil {
+			t.Fatalf("range 0-%d: expected an error past the chain height", end)
+		}
+		if len(headers) != 2000 {
+			t.Errorf("range 0-%d: got %d headers before the error, want 2000", end, len(headers))
+		}
+	}
+}
+
+func TestHeaderIterator(t *testing.T) {
+	srv := newHeaderRangeServer(t, 5000)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	it := c.iterateBlockHeaders(100, 1099, 4, 7)
+	defer it.Close()
+	want := uint64(100)
+	for it.Next() {
+		if h := it.Header(); h.Height != want {
+			t.Fatalf("got height %d, want %d", h.Height, want)
+		}
+		want++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want != 1100 || it.ResumeHeight() != 1100 {
+		t.Errorf("stopped at %d, resume height %d, want 1100", want, it.ResumeHeight())
+	}
+	if srv.peak > 4 {
+		t.Errorf("%d concurrent calls with 4 workers", srv.peak)
+	}
+}
+
+func TestHeaderIteratorResume(t *testing.T) {
+	srv := newHeaderRangeServer(t, 5000)
+	defer srv.Close()
+	srv.failHeight = 523
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	var heights []uint64
+	it := c.iterateBlockHeaders(0, 999, 3, 50)
+	for it.Next() {
+		heights = append(heights, it.Header().Height)
+	}
+	it.Close()
+	if it.Err() == nil {
+		t.Fatal("expected an error")
+	}
+	if it.ResumeHeight() != 500 || len(heights) != 500 {
+		t.Fatalf("got %d headers and resume height %d, want 500", len(heights), it.ResumeHeight())
+	}
+
+	srv.mu.Lock()
+	srv.failHeight = 0
+	srv.mu.Unlock()
+	it = c.iterateBlockHeaders(it.ResumeHeight(), 999, 3, 50)
+	defer it.Close()
+	for it.Next() {
+		heights = append(heights, it.Header().Height)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	for i, h := range heights {
+		if h != uint64(i) {
+			t.Fatalf("header %d has height %d", i, h)
+		}
+	}
+	if len(heights) != 1000 {
+		t.Errorf("got %d headers, want 1000", len(heights))
+	}
+}
+
+func TestHeaderIteratorClose(t *testing.T) {
+	srv := newHeaderRangeServer(t, 5000)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	it := c.iterateBlockHeaders(0, 4999, 2, 10)
+	if !it.Next() {
+		t.Fatal(it.Err())
+	}
+	it.Close()
+	it.Close()
+	if it.Next() {
+		t.Error("iterator keeps going after Close")
+	}
+	if it.Err() != ErrIteratorClosed {
+		t.Errorf("got %v, want ErrIteratorClosed", it.Err())
+	}
+}
@@ -0,0 +1,81 @@
+package monero
+
+import "fmt"
+
+// KeyImageBatchSize is the maximum number of key images a daemon in
+// restricted RPC mode checks in one is_key_image_spent call.
+const KeyImageBatchSize = 5000
+
+// KeyImageStatus is the spent status of a key image.
+type KeyImageStatus int
+
+const (
+	// KeyImageUnspent means the key image has not been spent.
+	KeyImageUnspent KeyImageStatus = iota
+
+	// KeyImageSpentInChain means the key image is spent by a transaction in
+	// the blockchain.
+	KeyImageSpentInChain
+
+	// KeyImageSpentInPool means the key image is spent by a transaction in
+	// the pool.
+	KeyImageSpentInPool
+)
+
+// Spent reports whether the key image is spent in the chain or the pool.
+func (s KeyImageStatus) Spent() bool {
+	return s == KeyImageSpentInChain || s == KeyImageSpentInPool
+}
+
+func (s KeyImageStatus) String() string {
+	switch s {
+	case KeyImageUnspent:
+		return "unspent"
+	case KeyImageSpentInChain:
+		return "spent in chain"
+	case KeyImageSpentInPool:
+		return "spent in pool"
+	}
+	return fmt.Sprintf("KeyImageStatus(%d)", int(s))
+}
+
+// IsKeyImageSpent returns the spent status of each key image, in the order
+// they were given. Large lists are checked in batches of KeyImageBatchSize.
+// The key images of a wallet can be exported with
+// WalletClient.ExportKeyImages.
+func (c *DaemonClient) IsKeyImageSpent(keyImages []string) ([]KeyImageStatus, error) {
+	statuses := make([]KeyImageStatus, 0, len(keyImages))
+	for start := 0; start < len(keyImages); start += KeyImageBatchSize {
+		end := start + KeyImageBatchSize
+		if end > len(keyImages) {
+			end = len(keyImages)
+		}
+		batch, err := c.isKeyImageSpent(keyImages[start:end])
+		if err != nil {
+			return statuses, err
+		}
+		statuses = append(statuses, batch...)
+	}
+	return statuses, nil
+}
+
+func (c *DaemonClient) isKeyImageSpent(keyImages []string) ([]KeyImageStatus, error) {
+	var response struct {
+		SpentStatus []KeyImageStatus `json:"spent_status"`
+		Status      string           `json:"status"`
+		Untrusted   bool             `json:"untrusted"`
+	}
+	request := struct {
+		KeyImages []string `json:"key_images"`
+	}{keyImages}
+	if err := c.DaemonOther("is_key_image_spent", &request, &response); err != nil {
+		return nil, err
+	}
+	if err := statusError("is_key_image_spent", response.Status); err != nil {
+		return nil, err
+	}
+	if len(response.SpentStatus) != len(keyImages) {
+		return nil, fmt.Errorf("is_key_image_spent: got %d statuses for %d key images", len(response.SpentStatus), len(keyImages))
+	}
+	return response.SpentStatus, nil
+}
@@ -0,0 +1,55 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsKeyImageSpentBatches(t *testing.T) {
+	var batches []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			KeyImages []string `json:"key_images"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, len(req.KeyImages))
+		// Key images ending in the digit of a status have that status.
+		statuses := make([]string, len(req.KeyImages))
+		for i, ki := range req.KeyImages {
+			statuses[i] = ki[len(ki)-1:]
+		}
+		fmt.Fprintf(w, `{"spent_status":[%s],"status":"OK"}`, strings.Join(statuses, ","))
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	keyImages := make([]string, 2*KeyImageBatchSize+1)
+	for i := range keyImages {
+		keyImages[i] = fmt.Sprintf("%063x%d", i, i%3)
+	}
+	statuses, err := c.IsKeyImageSpent(keyImages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(batches) != fmt.Sprintf("[%d %d 1]", KeyImageBatchSize, KeyImageBatchSize) {
+		t.Errorf("got batches %v", batches)
+	}
+	if len(statuses) != len(keyImages) {
+		t.Fatalf("got %d statuses for %d key images", len(statuses), len(keyImages))
+	}
+	for i, s := range statuses {
+		if s != KeyImageStatus(i%3) {
+			t.Fatalf("key image %d: got %v, want %v", i, s, KeyImageStatus(i%3))
+		}
+	}
+	if KeyImageUnspent.Spent() || !KeyImageSpentInChain.Spent() || !KeyImageSpentInPool.Spent() {
+		t.Error("wrong Spent")
+	}
+	if KeyImageSpentInPool.String() != "spent in pool" || KeyImageStatus(7).String() != "KeyImageStatus(7)" {
+		t.Error("wrong String")
+	}
+}
@@ -0,0 +1,178 @@
+package monero
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// MinerData
+// major_version - unsigned int; Major version of the next block.
+// height - unsigned int; Height of the next block.
+// prev_id - string; Hash of the block the next block builds on.
+// seed_hash - string; RandomX seed hash of the next block.
+// difficulty - string; Difficulty of the next block as a hex string.
+// median_weight - unsigned int; Median block weight, above which miners are penalized.
+// already_generated_coins - unsigned int; Coins minted before the next block, saturated at MoneySupply.
+// tx_backlog - List of the pool transactions a block template can include, see MinerDataTx.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type MinerData struct {
+	MajorVersion          uint8         `json:"major_version"`
+	Height                uint64        `json:"height"`
+	PrevID                string        `json:"prev_id"`
+	SeedHash              string        `json:"seed_hash"`
+	Difficulty            Difficulty    `json:"difficulty"`
+	MedianWeight          uint64        `json:"median_weight"`
+	AlreadyGeneratedCoins uint64        `json:"already_generated_coins"`
+	TxBacklog             []MinerDataTx `json:"tx_backlog,omitempty"`
+	Status                string        `json:"status"`
+	Untrusted             bool          `json:"untrusted"`
+}
+
+// MinerDataTx
+// id - string; Hash of the transaction.
+// weight - unsigned int; Weight of the transaction.
+// fee - unsigned int; Fee of the transaction in atomic units.
+type MinerDataTx struct {
+	ID     string `json:"id"`
+	Weight uint64 `json:"weight"`
+	Fee    uint64 `json:"fee"`
+}
+
+// GetMinerData returns what a miner needs to build the next block itself.
+func (c *DaemonClient) GetMinerData() (MinerData, error) {
+	var md MinerData
+	if err := c.Daemon("get_miner_data", nil, &md); err != nil {
+		return md, err
+	}
+	if err := statusError("get_miner_data", md.Status); err != nil {
+		return md, err
+	}
+	return md, nil
+}
+
+// CalcPow computes the proof of work hash of a block hashing blob, given as
+// hex, for a block of majorVersion at height with the RandomX seed hash.
+func (c *DaemonClient) CalcPow(majorVersion uint8, height uint64, blockBlob, seedHash string) (string, error) {
+	var hash string
+	request := struct {
+		MajorVersion uint8  `json:"major_version"`
+		Height       uint64 `json:"height"`
+		BlockBlob    string `json:"block_blob"`
+		SeedHash     string `json:"seed_hash"`
+	}{majorVersion, height, blockBlob, seedHash}
+	if err := c.Daemon("calc_pow", &request, &hash); err != nil {
+		return hash, err
+	}
+	return hash, nil
+}
+
+// AuxPow
+// id - string; Unique ID of the merge mined chain.
+// hash - string; Hash of the merge mined chain's block.
+type AuxPow struct {
+	ID   string `json:"id"`
+	Hash string `json:"hash"`
+}
+
+// AuxPowResult
+// blocktemplate_blob - string; The block template with the merge mining tag added.
+// blockhashing_blob - string; The hashing blob of the new block template.
+// merkle_root - string; Root of the merkle tree of the aux chain hashes.
+// merkle_tree_depth - unsigned int; Number of aux chains and the nonce of their slots, see DecodeMerkleTreeDepth.
+// aux_pow - List of the aux chains in the order of their tree slots.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type AuxPowResult struct {
+	BlockTemplateBlob string   `json:"blocktemplate_blob"`
+	BlockHashingBlob  string   `json:"blockhashing_blob"`
+	MerkleRoot        string   `json:"merkle_root"`
+	MerkleTreeDepth   uint64   `json:"merkle_tree_depth"`
+	AuxPow            []AuxPow `json:"aux_pow,omitempty"`
+	Status            string   `json:"status"`
+	Untrusted         bool     `json:"untrusted"`
+}
+
+// Params decodes the merkle tree parameters of the result.
+func (r AuxPowResult) Params() MerkleTreeParams {
+	return DecodeMerkleTreeDepth(r.MerkleTreeDepth)
+}
+
+// AddAuxPow adds a merge mining tag for the given aux chains to a block
+// template, given as hex.
+func (c *DaemonClient) AddAuxPow(blockTemplateBlob string, auxPow []AuxPow) (AuxPowResult, error) {
+	var ar AuxPowResult
+	request := struct {
+		BlockTemplateBlob string   `json:"blocktemplate_blob"`
+		AuxPow            []AuxPow `json:"aux_pow"`
+	}{blockTemplateBlob, auxPow}
+	if err := c.Daemon("add_aux_pow", &request, &ar); err != nil {
+		return ar, err
+	}
+	if err := statusError("add_aux_pow", ar.Status); err != nil {
+		return ar, err
+	}
+	return ar, nil
+}
+
+// MaxAuxChains is the most chains a merge mining tag can commit to.
+const MaxAuxChains = 256
+
+// hashKeyMMSlot is the domain separator of aux chain slot hashes.
+const hashKeyMMSlot = 'm'
+
+// MerkleTreeParams are the parameters packed into a merkle_tree_depth: the
+// number of aux chains in the tree and the nonce that places each chain in
+// its own slot.
+type MerkleTreeParams struct {
+	NumChains uint32
+	Nonce     uint32
+}
+
+// mmBits returns the number of bits needed for a slot index among n chains,
+// at least 1.
+func mmBits(n uint32) uint32 {
+	b := uint32(1)
+	for 1<<b < n && b < 16 {
+		b++
+	}
+	return b
+}
+
+// Depth packs the parameters into a merkle_tree_depth the way the daemon does.
+func (p MerkleTreeParams) Depth() (uint64, error) {
+	if p.NumChains == 0 || p.NumChains > MaxAuxChains {
+		return 0, fmt.Errorf("invalid number of aux chains %d", p.NumChains)
+	}
+	b := mmBits(p.NumChains)
+	return uint64(b-1) | uint64(p.NumChains-1)<<3 | uint64(p.Nonce)<<(3+b), nil
+}
+
+// DecodeMerkleTreeDepth unpacks the parameters of a merkle_tree_depth.
+func DecodeMerkleTreeDepth(depth uint64) MerkleTreeParams {
+	b := 1 + uint32(depth&7)
+	return MerkleTreeParams{
+		NumChains: 1 + uint32(depth>>3)&(1<<b-1),
+		Nonce:     uint32(depth >> (3 + b)),
+	}
+}
+
+// AuxSlot returns the tree slot of the aux chain with the given 32-byte ID,
+// given as hex.
+func (p MerkleTreeParams) AuxSlot(id string) (uint32, error) {
+	if p.NumChains == 0 {
+		return 0, fmt.Errorf("invalid number of aux chains 0")
+	}
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) != 32 {
+		return 0, fmt.Errorf("invalid aux chain id %q", id)
+	}
+	var buf [32 + 4 + 1]byte
+	copy(buf[:], raw)
+	binary.LittleEndian.PutUint32(buf[32:], p.Nonce)
+	buf[36] = hashKeyMMSlot
+	sum := sha256.Sum256(buf[:])
+	return binary.LittleEndian.Uint32(sum[:4]) % p.NumChains, nil
+}
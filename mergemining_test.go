@@ -0,0 +1,76 @@
+package monero
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMerkleTreeDepth(t *testing.T) {
+	for _, tc := range []struct {
+		params MerkleTreeParams
+		depth  uint64
+	}{
+		{MerkleTreeParams{NumChains: 1}, 0},
+		{MerkleTreeParams{NumChains: 2}, 8},
+		{MerkleTreeParams{NumChains: 2, Nonce: 1}, 24},
+		{MerkleTreeParams{NumChains: 2, Nonce: 3}, 56},
+		{MerkleTreeParams{NumChains: 5, Nonce: 0xdeadbeef}, 239099427810},
+		{MerkleTreeParams{NumChains: 256, Nonce: 7}, 16383},
+	} {
+		depth, err := tc.params.Depth()
+		if err != nil || depth != tc.depth {
+			t.Errorf("%+v: got depth %d, %v, want %d", tc.params, depth, err, tc.depth)
+		}
+		if got := DecodeMerkleTreeDepth(tc.depth); got != tc.params {
+			t.Errorf("%d: got %+v, want %+v", tc.depth, got, tc.params)
+		}
+	}
+	for _, n := range []uint32{0, 257} {
+		if _, err := (MerkleTreeParams{NumChains: n}).Depth(); err == nil {
+			t.Errorf("%d chains: expected an error", n)
+		}
+	}
+}
+
+func TestAuxSlot(t *testing.T) {
+	ids := []string{
+		"c992d2614026efccf6636e9f3e83cd4dfaa760729f2e5316f4c8bfe5d6a1ad0e",
+		"8c664297d0432f5e0206e8e0359b48d42130205844d3e0632bf44ba71dc93a0b",
+	}
+	for _, tc := range []struct {
+		nonce uint32
+		slots [2]uint32
+	}{
+		{0, [2]uint32{1, 0}},
+		{1, [2]uint32{0, 1}},
+		{2, [2]uint32{1, 1}},
+	} {
+		p := MerkleTreeParams{NumChains: 2, Nonce: tc.nonce}
+		for i, id := range ids {
+			slot, err := p.AuxSlot(id)
+			if err != nil || slot != tc.slots[i] {
+				t.Errorf("nonce %d, chain %d: got slot %d, %v, want %d", tc.nonce, i, slot, err, tc.slots[i])
+			}
+		}
+	}
+	if _, err := (MerkleTreeParams{NumChains: 2}).AuxSlot("d2b2"); err == nil {
+		t.Error("expected an error for a short id")
+	}
+}
+
+func TestMergeMiningStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"0","jsonrpc":"2.0","result":{"status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	if md, err := c.GetMinerData(); err == nil {
+		t.Errorf("GetMinerData: got %+v, want an error for a BUSY reply", md)
+	}
+	if ar, err := c.AddAuxPow("0e0e", []AuxPow{{ID: "00", Hash: "00"}}); err == nil {
+		t.Errorf("AddAuxPow: got %+v, want an error for a BUSY reply", ar)
+	}
+}
@@ -0,0 +1,244 @@
+package monero
+
+import (
+	"fmt"
+	"time"
+)
+
+// StartMining starts mining to address on the daemon with the given number of
+// threads. With background the daemon only mines while the machine is idle and
+// with ignoreBattery it also mines on battery power.
+func (c *DaemonClient) StartMining(address string, threads uint64, background, ignoreBattery bool) error {
+	request := struct {
+		MinerAddress     string `json:"miner_address"`
+		ThreadsCount     uint64 `json:"threads_count"`
+		BackgroundMining bool   `json:"do_background_mining"`
+		IgnoreBattery    bool   `json:"ignore_battery"`
+	}{address, threads, background, ignoreBattery}
+	return c.operator("start_mining", &request, nil)
+}
+
+// StopMining stops mining on the daemon.
+func (c *DaemonClient) StopMining() error {
+	return c.operator("stop_mining", nil, nil)
+}
+
+// MiningStatus
+// active - boolean; States if mining is enabled.
+// speed - unsigned int; Mining speed in hashes per second.
+// threads_count - unsigned int; Number of threads mining.
+// address - string; Account address mining rewards go to.
+// pow_algorithm - string; Current hashing algorithm name.
+// is_background_mining_enabled - boolean; States if background mining is enabled.
+// bg_idle_threshold - unsigned int; Background mining: CPU usage in percent below which the machine counts as idle.
+// bg_min_idle_seconds - unsigned int; Background mining: seconds the machine has to be idle before mining.
+// bg_ignore_battery - boolean; Background mining: states if mining continues on battery power.
+// bg_target - unsigned int; Background mining: CPU usage in percent to mine with.
+// block_target - unsigned int; The expected time to solve a block in seconds.
+// block_reward - unsigned int; Block reward for the current block being mined.
+// difficulty - unsigned int; The difficulty for the current block being mined, least significant 64 bits.
+// difficulty_top64 - unsigned int; Most significant 64 bits of the difficulty.
+// wide_difficulty - string; The difficulty as a hex string.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type MiningStatus struct {
+	Active                    bool       `json:"active"`
+	Speed                     uint64     `json:"speed"`
+	ThreadsCount              uint64     `json:"threads_count"`
+	Address                   string     `json:"address"`
+	PowAlgorithm              string     `json:"pow_algorithm"`
+	IsBackgroundMiningEnabled bool       `json:"is_background_mining_enabled"`
+	BgIdleThreshold           uint8      `json:"bg_idle_threshold"`
+	BgMinIdleSeconds          uint8      `json:"bg_min_idle_seconds"`
+	BgIgnoreBattery           bool       `json:"bg_ignore_battery"`
+	BgTarget                  uint8      `json:"bg_target"`
+	BlockTarget               uint64     `json:"block_target"`
+	BlockReward               uint64     `json:"block_reward"`
+	Difficulty                uint64     `json:"difficulty"`
+	DifficultyTop64           uint64     `json:"difficulty_top64"`
+	WideDifficulty            Difficulty `json:"wide_difficulty"`
+	Status                    string     `json:"status"`
+	Untrusted                 bool       `json:"untrusted"`
+}
+
+func (s MiningStatus) status() string {
+	return s.Status
+}
+
+// MiningStatus returns whether the daemon is mining and at what speed.
+func (c *DaemonClient) MiningStatus() (MiningStatus, error) {
+	var ms MiningStatus
+	if err := c.operator("mining_status", nil, &ms); err != nil {
+		return ms, err
+	}
+	return ms, nil
+}
+
+// MiningWindow is a daily period in local time, given as offsets from
+// midnight. A window whose End is before its Start runs past midnight.
+type MiningWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Contains reports whether t falls inside the window.
+func (w MiningWindow) Contains(t time.Time) bool {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := t.Sub(midnight)
+	if w.Start <= w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// MiningAction is what a MiningController did in a step.
+type MiningAction int
+
+const (
+	// MiningUnchanged means the daemon was left as it was.
+	MiningUnchanged MiningAction = iota
+
+	// MiningStarted means mining was started.
+	MiningStarted
+
+	// MiningStopped means mining was stopped.
+	MiningStopped
+
+	// MiningAdjusted means mining was restarted with another thread count
+	// to get closer to the target hashrate.
+	MiningAdjusted
+)
+
+func (a MiningAction) String() string {
+	switch a {
+	case MiningUnchanged:
+		return "unchanged"
+	case MiningStarted:
+		return "started"
+	case MiningStopped:
+		return "stopped"
+	case MiningAdjusted:
+		return "adjusted"
+	}
+	return fmt.Sprintf("MiningAction(%d)", int(a))
+}
+
+// MiningController keeps a daemon mining only inside its schedule windows and,
+// if a target hashrate is set, changes the thread count to stay within 10% of
+// it.
+type MiningController struct {
+	c   *DaemonClient
+	now func() time.Time
+
+	Address       string
+	Background    bool
+	IgnoreBattery bool
+
+	// MaxThreads is the most threads the controller mines with. Without a
+	// target hashrate it always mines with MaxThreads.
+	MaxThreads uint64
+
+	// TargetHashrate is the speed in hashes per second to aim for. 0 mines
+	// at full speed.
+	TargetHashrate uint64
+
+	// Windows are the periods mining is allowed in. Without windows mining
+	// is always allowed.
+	Windows []MiningWindow
+
+	// AdjustInterval is the least time between thread count changes, to let
+	// the speed the daemon reports settle.
+	AdjustInterval time.Duration
+
+	changed time.Time
+}
+
+// NewMiningController creates a MiningController that mines to address with
+// up to maxThreads threads. It adjusts the thread count at most once a minute.
+func NewMiningController(c *DaemonClient, address string, maxThreads uint64) *MiningController {
+	return &MiningController{c: c, now: time.Now, Address: address, MaxThreads: maxThreads, AdjustInterval: time.Minute}
+}
+
+// Scheduled reports whether the windows allow mining at t.
+func (m *MiningController) Scheduled(t time.Time) bool {
+	if len(m.Windows) == 0 {
+		return true
+	}
+	for _, w := range m.Windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Step checks the daemon's mining status once and starts, stops or adjusts
+// mining as needed.
+func (m *MiningController) Step() (MiningAction, error) {
+	ms, err := m.c.MiningStatus()
+	if err != nil {
+		return MiningUnchanged, err
+	}
+	now := m.now()
+	if !m.Scheduled(now) {
+		if !ms.Active {
+			return MiningUnchanged, nil
+		}
+		return MiningStopped, m.c.StopMining()
+	}
+	if !ms.Active {
+		threads := m.MaxThreads
+		if m.TargetHashrate > 0 {
+			threads = 1
+		}
+		m.changed = now
+		return MiningStarted, m.c.StartMining(m.Address, threads, m.Background, m.IgnoreBattery)
+	}
+	if now.Sub(m.changed) < m.AdjustInterval {
+		return MiningUnchanged, nil
+	}
+	threads := m.threads(ms)
+	if threads == ms.ThreadsCount {
+		return MiningUnchanged, nil
+	}
+	m.changed = now
+	if err := m.c.StopMining(); err != nil {
+		return MiningUnchanged, err
+	}
+	return MiningAdjusted, m.c.StartMining(m.Address, threads, m.Background, m.IgnoreBattery)
+}
+
+// threads returns the thread count to mine with given the current status.
+func (m *MiningController) threads(ms MiningStatus) uint64 {
+	threads := ms.ThreadsCount
+	if m.TargetHashrate == 0 {
+		return m.MaxThreads
+	}
+	margin := m.TargetHashrate / 10
+	switch {
+	case threads > m.MaxThreads:
+		threads = m.MaxThreads
+	case ms.Speed > m.TargetHashrate+margin && threads > 1:
+		threads--
+	case ms.Speed+margin < m.TargetHashrate && threads < m.MaxThreads:
+		threads++
+	}
+	return threads
+}
+
+// Run calls Step every interval until stop is closed, then stops mining.
+// Errors talking to the daemon are returned right away.
+func (m *MiningController) Run(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Step(); err != nil {
+			return err
+		}
+		select {
+		case <-stop:
+			return m.c.StopMining()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package monero
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const miningAddress = "47xu3gQpF569au9C2ajo5SSMrWji6xnoE5vhr94EzFRaKAGw6hEGFXYAwVADKuRpzsjiU1PtmaVgcjUJF89ghGPhUXkndHc"
+
+// minerServer answers start_mining, stop_mining and mining_status for a daemon
+// that mines hashesPerThread per thread.
+type minerServer struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	threads         uint64
+	hashesPerThread uint64
+	starts          int
+}
+
+func newMinerServer(t *testing.T, hashesPerThread uint64) *minerServer {
+	s := &minerServer{hashesPerThread: hashesPerThread}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var result interface{}
+		switch r.URL.Path {
+		case "/start_mining":
+			var req struct {
+				MinerAddress string `json:"miner_address"`
+				ThreadsCount uint64 `json:"threads_count"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			status := "OK"
+			if s.threads > 0 {
+				status = "Already mining"
+			} else if req.MinerAddress != miningAddress || req.ThreadsCount == 0 {
+				status = "Failed, wrong address"
+			}
+			s.threads = req.ThreadsCount
+			s.starts++
+			result = map[string]string{"status": status}
+		case "/stop_mining":
+			s.threads = 0
+			result = map[string]string{"status": "OK"}
+		case "/mining_status":
+			result = MiningStatus{Active: s.threads > 0, ThreadsCount: s.threads, Speed: s.threads * s.hashesPerThread, Status: "OK"}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(result)
+	}))
+	return s
+}
+
+func (s *minerServer) state() (threads uint64, starts int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.threads, s.starts
+}
+
+func TestMiningWindow(t *testing.T) {
+	day := MiningWindow{Start: 8 * time.Hour, End: 18 * time.Hour}
+	night := MiningWindow{Start: 22 * time.Hour, End: 6 * time.Hour}
+	for _, tc := range []struct {
+		hour, min  int
+		day, night bool
+	}{
+		{0, 0, false, true},
+		{5, 59, false, true},
+		{6, 0, false, false},
+		{8, 0, true, false},
+		{17, 59, true, false},
+		{18, 0, false, false},
+		{22, 0, false, true},
+		{23, 59, false, true},
+	} {
+		at := time.Date(2021, 1, 31, tc.hour, tc.min, 0, 0, time.UTC)
+		if got := day.Contains(at); got != tc.day {
+			t.Errorf("%02d:%02d day: got %v", tc.hour, tc.min, got)
+		}
+		if got := night.Contains(at); got != tc.night {
+			t.Errorf("%02d:%02d night: got %v", tc.hour, tc.min, got)
+		}
+	}
+}
+
+func TestMiningController(t *testing.T) {
+	srv := newMinerServer(t, 500)
+	defer srv.Close()
+	m := NewMiningController(NewDaemonClient(srv.URL+"/json_rpc"), miningAddress, 8)
+	m.TargetHashrate = 2000
+	m.Windows = []MiningWindow{{Start: 8 * time.Hour, End: 18 * time.Hour}}
+	now := time.Date(2021, 1, 31, 7, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	step := func(want MiningAction, wantThreads uint64) {
+		t.Helper()
+		got, err := m.Step()
+		if err != nil {
+			t.Fatal(err)
+		}
+		threads, _ := srv.state()
+		if got != want || threads != wantThreads {
+			t.Fatalf("%s: got %s with %d threads, want %s with %d", now.Format("15:04"), got, threads, want, wantThreads)
+		}
+	}
+
+	step(MiningUnchanged, 0)
+	now = now.Add(time.Hour)
+	step(MiningStarted, 1)
+	now = now.Add(30 * time.Second)
+	step(MiningUnchanged, 1)
+	for threads := uint64(2); threads <= 4; threads++ {
+		now = now.Add(time.Minute)
+		step(MiningAdjusted, threads)
+	}
+	now = now.Add(time.Minute)
+	step(MiningUnchanged, 4)
+
+	m.TargetHashrate = 1000
+	now = now.Add(time.Minute)
+	step(MiningAdjusted, 3)
+
+	m.TargetHashrate = 0
+	now = now.Add(time.Minute)
+	step(MiningAdjusted, 8)
+
+	now = time.Date(2021, 1, 31, 18, 0, 0, 0, time.UTC)
+	step(MiningStopped, 0)
+	step(MiningUnchanged, 0)
+	if _, starts := srv.state(); starts != 6 {
+		t.Errorf("mining started %d times, want 6", starts)
+	}
+}
+
+func TestMiningControllerRun(t *testing.T) {
+	srv := newMinerServer(t, 500)
+	defer srv.Close()
+	m := NewMiningController(NewDaemonClient(srv.URL+"/json_rpc"), miningAddress, 2)
+
+	stop := make(chan struct{})
+	done := make(chan error)
+	go func() { done <- m.Run(time.Millisecond, stop) }()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		if threads, _ := srv.state(); threads == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("mining did not start")
+		}
+	}
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if threads, starts := srv.state(); threads != 0 || starts != 1 {
+		t.Errorf("got %d threads after %d starts, want mining stopped after 1", threads, starts)
+	}
+
+	m.Address = "4invalid"
+	srv.mu.Lock()
+	srv.threads = 0
+	srv.mu.Unlock()
+	if err := m.Run(time.Millisecond, nil); err == nil {
+		t.Error("expected an error for a refused start")
+	}
+}
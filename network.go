@@ -0,0 +1,236 @@
+package monero
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// NetworkType is the network a daemon runs on or an address belongs to.
+type NetworkType uint8
+
+const (
+	// Mainnet is the main Monero network.
+	Mainnet NetworkType = iota
+
+	// Testnet is the public test network, which forks ahead of mainnet.
+	Testnet
+
+	// Stagenet is the public staging network, which forks with mainnet.
+	Stagenet
+
+	// Fakechain is a local regtest chain. It uses mainnet addresses.
+	Fakechain
+)
+
+var networkNames = [...]string{"mainnet", "testnet", "stagenet", "fakechain"}
+
+func (n NetworkType) String() string {
+	if int(n) < len(networkNames) {
+		return networkNames[n]
+	}
+	return fmt.Sprintf("NetworkType(%d)", int(n))
+}
+
+// ParseNetworkType parses the nettype the daemon reports in get_info.
+func ParseNetworkType(s string) (NetworkType, error) {
+	for i, name := range networkNames {
+		if s == name {
+			return NetworkType(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown network type %q", s)
+}
+
+// MarshalText encodes the network type as its name.
+func (n NetworkType) MarshalText() ([]byte, error) {
+	if int(n) >= len(networkNames) {
+		return nil, fmt.Errorf("unknown network type %d", int(n))
+	}
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText decodes a network type name.
+func (n *NetworkType) UnmarshalText(text []byte) error {
+	v, err := ParseNetworkType(string(text))
+	if err != nil {
+		return err
+	}
+	*n = v
+	return nil
+}
+
+// Network returns the network the daemon runs on. Daemons that predate the
+// nettype field only report the testnet and stagenet flags.
+func (i Info) Network() NetworkType {
+	switch {
+	case i.Testnet:
+		return Testnet
+	case i.Stagenet:
+		return Stagenet
+	case i.Mainnet:
+		return Mainnet
+	}
+	return i.NetType
+}
+
+// AddressKind is the kind of a Monero address.
+type AddressKind int
+
+const (
+	// AddressStandard is the primary address of a wallet.
+	AddressStandard AddressKind = iota
+
+	// AddressIntegrated is a standard address with a payment id.
+	AddressIntegrated
+
+	// AddressSubaddress is a subaddress of a wallet.
+	AddressSubaddress
+)
+
+func (k AddressKind) String() string {
+	switch k {
+	case AddressStandard:
+		return "standard"
+	case AddressIntegrated:
+		return "integrated"
+	case AddressSubaddress:
+		return "subaddress"
+	}
+	return fmt.Sprintf("AddressKind(%d)", int(k))
+}
+
+// AddressPrefixes are the base58 prefixes of the addresses of a network.
+type AddressPrefixes struct {
+	Standard   uint64
+	Integrated uint64
+	Subaddress uint64
+}
+
+// AddressPrefixes returns the address prefixes of the network.
+func (n NetworkType) AddressPrefixes() AddressPrefixes {
+	switch n {
+	case Testnet:
+		return AddressPrefixes{Standard: 53, Integrated: 54, Subaddress: 63}
+	case Stagenet:
+		return AddressPrefixes{Standard: 24, Integrated: 25, Subaddress: 36}
+	}
+	return AddressPrefixes{Standard: 18, Integrated: 19, Subaddress: 42}
+}
+
+func (p AddressPrefixes) kind(prefix uint64) (AddressKind, bool) {
+	switch prefix {
+	case p.Standard:
+		return AddressStandard, true
+	case p.Integrated:
+		return AddressIntegrated, true
+	case p.Subaddress:
+		return AddressSubaddress, true
+	}
+	return 0, false
+}
+
+// ErrInvalidAddress is returned for strings that are not Monero addresses.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// Sizes of the parts of a decoded address.
+const (
+	addressChecksumSize = 4
+	integratedIDSize    = 8
+)
+
+// AddressNetwork returns the network and kind of a Monero address, after
+// checking its checksum. Fakechain addresses are reported as Mainnet.
+func AddressNetwork(address string) (NetworkType, AddressKind, error) {
+	data, err := decodeBase58(address)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	if len(data) < addressChecksumSize {
+		return 0, 0, fmt.Errorf("%w: too short", ErrInvalidAddress)
+	}
+	body, checksum := data[:len(data)-addressChecksumSize], data[len(data)-addressChecksumSize:]
+	if sum := keccak256(body); !bytes.Equal(sum[:addressChecksumSize], checksum) {
+		return 0, 0, fmt.Errorf("%w: bad checksum", ErrInvalidAddress)
+	}
+	prefix, n := binary.Uvarint(body)
+	if n <= 0 {
+		return 0, 0, fmt.Errorf("%w: bad prefix", ErrInvalidAddress)
+	}
+	for _, network := range []NetworkType{Mainnet, Testnet, Stagenet} {
+		kind, ok := network.AddressPrefixes().kind(prefix)
+		if !ok {
+			continue
+		}
+		size := 2 * keySize
+		if kind == AddressIntegrated {
+			size += integratedIDSize
+		}
+		if len(body)-n != size {
+			return 0, 0, fmt.Errorf("%w: %d bytes of keys for a %s address", ErrInvalidAddress, len(body)-n, kind)
+		}
+		return network, kind, nil
+	}
+	return 0, 0, fmt.Errorf("%w: unknown prefix %d", ErrInvalidAddress, prefix)
+}
+
+// CheckAddress returns an error if address is not a valid address of the
+// network.
+func (n NetworkType) CheckAddress(address string) error {
+	network, _, err := AddressNetwork(address)
+	if err != nil {
+		return err
+	}
+	if n == Fakechain {
+		n = Mainnet
+	}
+	if network != n {
+		return fmt.Errorf("%w: %s address on %s", ErrInvalidAddress, network, n)
+	}
+	return nil
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58BlockSizes maps the length of an encoded block to the number of
+// bytes it holds. Monero encodes 8-byte blocks as 11 characters, the last
+// block is shorter.
+var base58BlockSizes = map[int]int{2: 1, 3: 2, 5: 3, 6: 4, 7: 5, 9: 6, 10: 7, 11: 8}
+
+// decodeBase58 decodes Monero's block-wise base58.
+func decodeBase58(s string) ([]byte, error) {
+	out := make([]byte, 0, len(s)*8/11+8)
+	for len(s) > 0 {
+		block := s
+		if len(block) > 11 {
+			block = block[:11]
+		}
+		s = s[len(block):]
+		size, ok := base58BlockSizes[len(block)]
+		if !ok {
+			return nil, errors.New("invalid base58 length")
+		}
+		var v uint64
+		for i := 0; i < len(block); i++ {
+			d := strings.IndexByte(base58Alphabet, block[i])
+			if d < 0 {
+				return nil, fmt.Errorf("invalid base58 character %q", block[i])
+			}
+			hi, lo := bits.Mul64(v, 58)
+			if hi != 0 || lo+uint64(d) < lo {
+				return nil, errors.New("base58 block overflows")
+			}
+			v = lo + uint64(d)
+		}
+		if size < 8 && v>>(8*uint(size)) != 0 {
+			return nil, errors.New("base58 block overflows")
+		}
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], v)
+		out = append(out, buf[8-size:]...)
+	}
+	return out, nil
+}
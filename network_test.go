@@ -0,0 +1,75 @@
+package monero
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNetworkType(t *testing.T) {
+	for _, n := range []NetworkType{Mainnet, Testnet, Stagenet, Fakechain} {
+		data, err := json.Marshal(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got NetworkType
+		if err := json.Unmarshal(data, &got); err != nil || got != n {
+			t.Errorf("%s: round tripped through %s as %s, %v", n, data, got, err)
+		}
+	}
+	if _, err := ParseNetworkType("regtest"); err == nil {
+		t.Error("parsed an unknown network type")
+	}
+	if got := NetworkType(9).String(); got != "NetworkType(9)" {
+		t.Errorf("got %q", got)
+	}
+
+	var info Info
+	if err := json.Unmarshal([]byte(`{"nettype":"fakechain"}`), &info); err != nil || info.Network() != Fakechain {
+		t.Errorf("got %s, %v", info.Network(), err)
+	}
+	// Daemons without nettype only send the flags.
+	if got := (Info{Stagenet: true}).Network(); got != Stagenet {
+		t.Errorf("got %s for the stagenet flag", got)
+	}
+}
+
+func TestAddressNetwork(t *testing.T) {
+	for _, c := range []struct {
+		address string
+		network NetworkType
+		kind    AddressKind
+	}{
+		{miningAddress, Mainnet, AddressStandard},
+		{"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt", Stagenet, AddressStandard},
+		{"5F38Rw9HKeaLQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZXCkbHUXdPHyiUeRyokn", Stagenet, AddressIntegrated},
+		{"7BnERTpvL5MbCLtj5n9No7J5oE5hHiB3tVCK5cjSvCsYWD2WRJLFuWeKTLiXo5QJqt2ZwUaLy2Vh1Ad51K7FNgqcHgjW85o", Stagenet, AddressSubaddress},
+	} {
+		network, kind, err := AddressNetwork(c.address)
+		if err != nil || network != c.network || kind != c.kind {
+			t.Errorf("%s: got %s %s, %v, want %s %s", c.address, network, kind, err, c.network, c.kind)
+		}
+		if err := c.network.CheckAddress(c.address); err != nil {
+			t.Errorf("%s: %v", c.address, err)
+		}
+	}
+
+	if err := Fakechain.CheckAddress(miningAddress); err != nil {
+		t.Errorf("fakechain rejected a mainnet address: %v", err)
+	}
+	if err := Testnet.CheckAddress(miningAddress); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("testnet accepted a mainnet address: %v", err)
+	}
+	for _, address := range []string{
+		"",
+		"47xu3g",
+		miningAddress[:94] + "d",
+		miningAddress[:50] + "0" + miningAddress[51:],
+		miningAddress[:94],
+		"zzzzzzzzzzz",
+	} {
+		if _, _, err := AddressNetwork(address); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("%q: got %v, want ErrInvalidAddress", address, err)
+		}
+	}
+}
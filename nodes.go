@@ -0,0 +1,218 @@
+package monero
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PublicNode
+// host - string; The node's IP address or host name.
+// last_seen - unsigned int; Unix time the node was last seen, 0 for gray nodes.
+// rpc_port - unsigned int; The port of the node's public RPC.
+// rpc_credits_per_hash - unsigned int; Credits the node's RPC pays per hash.
+type PublicNode struct {
+	Host              string `json:"host"`
+	LastSeen          uint64 `json:"last_seen"`
+	RPCPort           uint   `json:"rpc_port"`
+	RPCCreditsPerHash uint   `json:"rpc_credits_per_hash"`
+}
+
+// Address returns the host and RPC port of the node.
+func (n PublicNode) Address() string {
+	return net.JoinHostPort(n.Host, strconv.FormatUint(uint64(n.RPCPort), 10))
+}
+
+// Endpoint returns the JSON-RPC endpoint of the node, for NewDaemonClient.
+func (n PublicNode) Endpoint() string {
+	return "http://" + n.Address() + "/json_rpc"
+}
+
+// PublicNodes
+// gray - List of public nodes the daemon has only heard of.
+// white - List of public nodes the daemon has connected to before.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type PublicNodes struct {
+	Gray      []PublicNode `json:"gray,omitempty"`
+	White     []PublicNode `json:"white,omitempty"`
+	Status    string       `json:"status"`
+	Untrusted bool         `json:"untrusted"`
+}
+
+// GetPublicNodes returns the peers that advertise a public RPC port, from the
+// gray and white peer lists as asked for. With includeBlocked banned peers
+// are included.
+func (c *DaemonClient) GetPublicNodes(gray, white, includeBlocked bool) (PublicNodes, error) {
+	var pn PublicNodes
+	request := struct {
+		Gray           bool `json:"gray"`
+		White          bool `json:"white"`
+		IncludeBlocked bool `json:"include_blocked"`
+	}{gray, white, includeBlocked}
+	if err := c.DaemonOther("get_public_nodes", &request, &pn); err != nil {
+		return pn, err
+	}
+	if err := statusError("get_public_nodes", pn.Status); err != nil {
+		return pn, err
+	}
+	return pn, nil
+}
+
+// SetBootstrapDaemon sets the daemon the node relays requests to while it is
+// syncing. address is host:port, "auto" to pick a public node, or empty to
+// stop using a bootstrap daemon. proxy is an optional SOCKS proxy to reach it
+// through, as host:port.
+func (c *DaemonClient) SetBootstrapDaemon(address, username, password, proxy string) error {
+	request := struct {
+		Address  string `json:"address"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
+		Proxy    string `json:"proxy,omitempty"`
+	}{address, username, password, proxy}
+	return c.operator("set_bootstrap_daemon", &request, nil)
+}
+
+// NodeCandidate is a public node that answered a probe.
+type NodeCandidate struct {
+	Node PublicNode
+
+	// Latency is how long the node took to answer get_info.
+	Latency time.Duration
+
+	// Lag is how many blocks the node is behind the trusted daemon.
+	Lag uint64
+
+	// Info is the node's answer to get_info.
+	Info Info
+}
+
+// score orders candidates, lower is better.
+func (n NodeCandidate) score(lagCost time.Duration) time.Duration {
+	return n.Latency + time.Duration(n.Lag)*lagCost
+}
+
+// NodeDiscovery finds remote nodes for light clients. It takes the public
+// nodes a trusted daemon knows, probes each with GetInfo and ranks the ones
+// on the trusted daemon's network by latency and freshness.
+type NodeDiscovery struct {
+	c *DaemonClient
+
+	// Gray also probes nodes from the gray peer list, which the trusted
+	// daemon has never connected to.
+	Gray bool
+
+	// Timeout bounds each probe.
+	Timeout time.Duration
+
+	// Workers is the number of concurrent probes.
+	Workers int
+
+	// MaxLag is the most blocks a candidate may be behind, or ahead of, the
+	// trusted daemon.
+	MaxLag uint64
+
+	// LagCost is the latency each block of lag counts as when ranking.
+	LagCost time.Duration
+}
+
+// NewNodeDiscovery creates a NodeDiscovery that takes public nodes from the
+// trusted daemon c, probing 8 at a time with a 5 second timeout and
+// accepting nodes up to 2 blocks away from its height.
+func NewNodeDiscovery(c *DaemonClient) *NodeDiscovery {
+	return &NodeDiscovery{
+		c:       c,
+		Timeout: 5 * time.Second,
+		Workers: 8,
+		MaxLag:  2,
+		LagCost: 500 * time.Millisecond,
+	}
+}
+
+func (d *NodeDiscovery) client(n PublicNode) *DaemonClient {
+	c := NewDaemonClient(n.Endpoint())
+	c.SetHTTPClient(&http.Client{Timeout: d.Timeout})
+	return c
+}
+
+// Discover probes the public nodes of the trusted daemon and returns the
+// candidates, best first. Nodes that fail the probe, run on another network,
+// are still syncing, answer from a bootstrap daemon or are more than MaxLag
+// blocks away from the trusted daemon's height are left out.
+func (d *NodeDiscovery) Discover() ([]NodeCandidate, error) {
+	trusted, err := d.c.GetInfo()
+	if err != nil {
+		return nil, err
+	}
+	pn, err := d.c.GetPublicNodes(d.Gray, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var nodes []PublicNode
+	for _, n := range append(pn.White, pn.Gray...) {
+		if n.RPCPort == 0 || seen[n.Address()] {
+			continue
+		}
+		seen[n.Address()] = true
+		nodes = append(nodes, n)
+	}
+
+	workers := d.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	var (
+		mu         sync.Mutex
+		candidates []NodeCandidate
+		wg         sync.WaitGroup
+	)
+	queue := make(chan PublicNode)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range queue {
+				if cand, ok := d.probe(n, trusted); ok {
+					mu.Lock()
+					candidates = append(candidates, cand)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, n := range nodes {
+		queue <- n
+	}
+	close(queue)
+	wg.Wait()
+
+	sort.Slice(candidates, func(i, j int) bool {
+		si, sj := candidates[i].score(d.LagCost), candidates[j].score(d.LagCost)
+		if si != sj {
+			return si < sj
+		}
+		return candidates[i].Node.Address() < candidates[j].Node.Address()
+	})
+	return candidates, nil
+}
+
+// probe asks n for its info and checks it against the trusted daemon's.
+func (d *NodeDiscovery) probe(n PublicNode, trusted Info) (NodeCandidate, bool) {
+	start := time.Now()
+	info, err := d.client(n).GetInfo()
+	cand := NodeCandidate{Node: n, Latency: time.Since(start), Info: info}
+	if err != nil || info.Untrusted || info.BusySyncing || info.Network() != trusted.Network() {
+		return cand, false
+	}
+	if info.Height < trusted.Height {
+		cand.Lag = uint64(trusted.Height - info.Height)
+		return cand, cand.Lag <= d.MaxLag
+	}
+	// Nodes far ahead of the trusted daemon are on another chain or lying.
+	return cand, uint64(info.Height-trusted.Height) <= d.MaxLag
+}
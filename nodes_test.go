@@ -0,0 +1,115 @@
+package monero
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// nodeServer answers get_info with info after delay.
+func nodeServer(info Info, delay time.Duration) *httptest.Server {
+	info.Status = "OK"
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "0", "jsonrpc": "2.0", "result": info})
+	}))
+}
+
+// publicNode returns the public node of a test server.
+func publicNode(t *testing.T, srv *httptest.Server) PublicNode {
+	host, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return PublicNode{Host: host, RPCPort: uint(p)}
+}
+
+func TestNodeDiscovery(t *testing.T) {
+	const height = 2286455
+	nodes := map[string]*httptest.Server{
+		"fast":     nodeServer(Info{Height: height, NetType: Mainnet}, 0),
+		"slow":     nodeServer(Info{Height: height, NetType: Mainnet}, 60*time.Millisecond),
+		"behind":   nodeServer(Info{Height: height - 1, NetType: Mainnet}, 0),
+		"stale":    nodeServer(Info{Height: height - 10, NetType: Mainnet}, 0),
+		"ahead":    nodeServer(Info{Height: height + 10, NetType: Mainnet}, 0),
+		"stagenet": nodeServer(Info{Height: height, NetType: Stagenet}, 0),
+		"syncing":  nodeServer(Info{Height: height, NetType: Mainnet, BusySyncing: true}, 0),
+		"boot":     nodeServer(Info{Height: height, NetType: Mainnet, Untrusted: true}, 0),
+		"hung":     nodeServer(Info{Height: height, NetType: Mainnet}, time.Second),
+		"gray":     nodeServer(Info{Height: height, NetType: Mainnet}, 0),
+		"dead":     nodeServer(Info{}, 0),
+	}
+	names := make(map[string]string)
+	var white []PublicNode
+	for name, srv := range nodes {
+		defer srv.Close()
+		n := publicNode(t, srv)
+		names[n.Address()] = name
+		if name != "gray" {
+			white = append(white, n)
+		}
+	}
+	nodes["dead"].Close()
+	white = append(white, white[0], PublicNode{Host: "203.0.113.34"})
+	gray := []PublicNode{publicNode(t, nodes["gray"])}
+
+	trusted := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json_rpc":
+			info := Info{Height: height, NetType: Mainnet, Status: "OK"}
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "0", "jsonrpc": "2.0", "result": info})
+		case "/get_public_nodes":
+			var req struct {
+				Gray bool `json:"gray"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			pn := PublicNodes{White: white, Status: "OK"}
+			if req.Gray {
+				pn.Gray = gray
+			}
+			json.NewEncoder(w).Encode(pn)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer trusted.Close()
+
+	d := NewNodeDiscovery(NewDaemonClient(trusted.URL + "/json_rpc"))
+	d.Timeout = 300 * time.Millisecond
+	d.MaxLag = 2
+	d.LagCost = 30 * time.Millisecond
+	candidates, err := d.Discover()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range candidates {
+		got = append(got, names[c.Node.Address()])
+	}
+	// One block of lag costs less than the 60ms of the slow node.
+	want := []string{"fast", "behind", "slow"}
+	if len(got) != len(want) {
+		t.Fatalf("got candidates %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got candidates %v, want %v", got, want)
+		}
+	}
+	if candidates[1].Lag != 1 || candidates[2].Latency < 60*time.Millisecond {
+		t.Errorf("got candidates %+v", candidates)
+	}
+
+	d.Gray = true
+	if candidates, err = d.Discover(); err != nil || len(candidates) != 4 {
+		t.Errorf("got %d candidates with gray nodes, %v", len(candidates), err)
+	}
+}
@@ -0,0 +1,157 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// OutputHistogramRequest
+// amounts - List of amounts to look up, empty for all amounts.
+// min_count - unsigned int; Only return amounts with at least this many outputs.
+// max_count - unsigned int; Only return amounts with at most this many outputs, 0 for no limit.
+// unlocked - boolean; Only count unlocked outputs.
+// recent_cutoff - unsigned int; Unix time after which outputs count as recent.
+type OutputHistogramRequest struct {
+	Amounts      []uint64 `json:"amounts"`
+	MinCount     uint64   `json:"min_count"`
+	MaxCount     uint64   `json:"max_count"`
+	Unlocked     bool     `json:"unlocked"`
+	RecentCutoff uint64   `json:"recent_cutoff"`
+}
+
+// OutputHistogramEntry
+// amount - unsigned int; Output amount in atomic units.
+// total_instances - unsigned int; Number of outputs of the amount.
+// unlocked_instances - unsigned int; Number of unlocked outputs of the amount.
+// recent_instances - unsigned int; Number of outputs of the amount newer than recent_cutoff.
+type OutputHistogramEntry struct {
+	Amount            uint64 `json:"amount"`
+	TotalInstances    uint64 `json:"total_instances"`
+	UnlockedInstances uint64 `json:"unlocked_instances"`
+	RecentInstances   uint64 `json:"recent_instances"`
+}
+
+// OutputDistributionRequest
+// amounts - List of amounts to look up, 0 for RingCT outputs.
+// from_height - unsigned int; Starting height.
+// to_height - unsigned int; Ending height, 0 for the current height.
+// cumulative - boolean; Return the number of outputs up to each height rather than at each height.
+// binary - boolean; Send the distribution as a binary blob, which is smaller.
+// compress - boolean; Compress the binary distribution, only used with binary.
+type OutputDistributionRequest struct {
+	Amounts    []uint64 `json:"amounts"`
+	FromHeight uint64   `json:"from_height"`
+	ToHeight   uint64   `json:"to_height"`
+	Cumulative bool     `json:"cumulative"`
+	Binary     bool     `json:"binary"`
+	Compress   bool     `json:"compress"`
+}
+
+// OutputDistribution
+// amount - unsigned int; Output amount in atomic units.
+// start_height - unsigned int; Height of the first entry of the distribution.
+// base - unsigned int; Number of outputs before start_height.
+// distribution - List of output counts per block from start_height on, cumulative if requested.
+// binary - boolean; The distribution was sent as a binary blob.
+// compress - boolean; The distribution was sent compressed.
+//
+// Binary and compressed distributions are decoded into Distribution.
+type OutputDistribution struct {
+	Amount       uint64   `json:"amount"`
+	StartHeight  uint64   `json:"start_height"`
+	Base         uint64   `json:"base"`
+	Distribution []uint64 `json:"distribution"`
+	Binary       bool     `json:"binary"`
+	Compress     bool     `json:"compress"`
+}
+
+// UnmarshalJSON decodes the distribution from whichever of the plain, binary
+// and compressed forms the daemon sent.
+func (d *OutputDistribution) UnmarshalJSON(data []byte) error {
+	type plain OutputDistribution
+	var aux struct {
+		plain
+		Distribution   json.RawMessage `json:"distribution"`
+		CompressedData json.RawMessage `json:"compressed_data"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*d = OutputDistribution(aux.plain)
+
+	switch {
+	case len(aux.CompressedData) > 0:
+		blob, err := decodeBlobString(aux.CompressedData)
+		if err != nil {
+			return err
+		}
+		d.Distribution, err = decompressIntegerArray(blob)
+		return err
+	case len(aux.Distribution) > 0 && aux.Distribution[0] == '"':
+		blob, err := decodeBlobString(aux.Distribution)
+		if err != nil {
+			return err
+		}
+		if len(blob)%8 != 0 {
+			return fmt.Errorf("distribution size %d is not a multiple of 8", len(blob))
+		}
+		d.Distribution = make([]uint64, len(blob)/8)
+		for i := range d.Distribution {
+			d.Distribution[i] = binary.LittleEndian.Uint64(blob[i*8:])
+		}
+		return nil
+	case len(aux.Distribution) > 0:
+		return json.Unmarshal(aux.Distribution, &d.Distribution)
+	}
+	return nil
+}
+
+// decompressIntegerArray decodes the varints the daemon compresses output
+// distributions into.
+func decompressIntegerArray(data []byte) ([]uint64, error) {
+	var values []uint64
+	for len(data) > 0 {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, errors.New("invalid varint in compressed distribution")
+		}
+		values = append(values, v)
+		data = data[n:]
+	}
+	return values, nil
+}
+
+// GetOutputHistogram returns the number of outputs of each amount.
+func (c *DaemonClient) GetOutputHistogram(req OutputHistogramRequest) ([]OutputHistogramEntry, error) {
+	var response struct {
+		Histogram []OutputHistogramEntry `json:"histogram,omitempty"`
+		Status    string                 `json:"status"`
+		Untrusted bool                   `json:"untrusted"`
+	}
+	if err := c.Daemon("get_output_histogram", &req, &response); err != nil {
+		return nil, err
+	}
+	if err := statusError("get_output_histogram", response.Status); err != nil {
+		return nil, err
+	}
+	return response.Histogram, nil
+}
+
+// GetOutputDistribution returns the number of outputs per block for each
+// amount, as used by wallets to pick decoys.
+func (c *DaemonClient) GetOutputDistribution(req OutputDistributionRequest) ([]OutputDistribution, error) {
+	var response struct {
+		Distributions []OutputDistribution `json:"distributions,omitempty"`
+		Status        string               `json:"status"`
+		Untrusted     bool                 `json:"untrusted"`
+	}
+	if err := c.Daemon("get_output_distribution", &req, &response); err != nil {
+		return nil, err
+	}
+	if err := statusError("get_output_distribution", response.Status); err != nil {
+		return nil, err
+	}
+	return response.Distributions, nil
+}
@@ -0,0 +1,72 @@
+package monero
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestOutputDistributionForms(t *testing.T) {
+	want := []uint64{40213819, 40213863, 40213891}
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"plain", `{"amount":0,"base":40213788,"binary":false,"compress":false,"distribution":[40213819,40213863,40213891],"start_height":2286440}`},
+		{"binary", "{\"amount\":0,\"base\":40213788,\"binary\":true,\"compress\":false,\"distribution\":\";\x9de\\u0002\\u0000\\u0000\\u0000\\u0000g\x9de\\u0002\\u0000\\u0000\\u0000\\u0000\x83\x9de\\u0002\\u0000\\u0000\\u0000\\u0000\",\"start_height\":2286440}"},
+		{"compressed", "{\"amount\":0,\"base\":40213788,\"binary\":true,\"compress\":true,\"compressed_data\":\"\xbb\xba\x96\\u0013\xe7\xba\x96\\u0013\x83\xbb\x96\\u0013\",\"start_height\":2286440}"},
+	}
+	for _, tc := range tests {
+		var d OutputDistribution
+		if err := json.Unmarshal([]byte(tc.data), &d); err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(d.Distribution, want) || d.Base != 40213788 || d.StartHeight != 2286440 {
+			t.Errorf("%s: got %+v", tc.name, d)
+		}
+	}
+
+	var d OutputDistribution
+	if err := json.Unmarshal([]byte("{\"compressed_data\":\"\xff\"}"), &d); err == nil {
+		t.Error("expected an error for a truncated varint")
+	}
+	if err := json.Unmarshal([]byte(`{"distribution":"\u0001\u0002"}`), &d); err == nil {
+		t.Error("expected an error for a short binary distribution")
+	}
+}
+
+func TestGetOutputDistribution(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	dists, err := c.GetOutputDistribution(OutputDistributionRequest{Amounts: []uint64{0}, Binary: true, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dists) != 1 || len(dists[0].Distribution) != 15 {
+		t.Fatalf("got %+v", dists)
+	}
+	if got := dists[0].Distribution[14]; got != 76251238 {
+		t.Errorf("last entry %d, want 76251238", got)
+	}
+}
+
+func TestOutputsStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"0","jsonrpc":"2.0","result":{"status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	if _, err := c.GetOutputHistogram(OutputHistogramRequest{}); err == nil {
+		t.Error("GetOutputHistogram: expected an error for a BUSY reply")
+	}
+	if _, err := c.GetOutputDistribution(OutputDistributionRequest{}); err == nil {
+		t.Error("GetOutputDistribution: expected an error for a BUSY reply")
+	}
+}
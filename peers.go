@@ -0,0 +1,255 @@
+package monero
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// validateBanHost checks that host is empty, an IP address, an IPv4 subnet or
+// an onion or i2p address, the forms setbans accepts. Onion and i2p addresses
+// are passed on for the daemon to check.
+func validateBanHost(host string) error {
+	if host == "" || net.ParseIP(host) != nil {
+		return nil
+	}
+	if strings.HasSuffix(host, ".onion") || strings.HasSuffix(host, ".i2p") {
+		return nil
+	}
+	ip, _, err := net.ParseCIDR(host)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("invalid ban host %q: not an IP address, IPv4 subnet, onion or i2p address", host)
+	}
+	return nil
+}
+
+// Banned reports whether address is banned and for how many more seconds.
+func (c *DaemonClient) Banned(address string) (banned bool, seconds uint, err error) {
+	var response struct {
+		Banned  bool   `json:"banned"`
+		Seconds uint   `json:"seconds"`
+		Status  string `json:"status"`
+	}
+	request := struct {
+		Address string `json:"address"`
+	}{address}
+	if err := c.Daemon("banned", &request, &response); err != nil {
+		return false, 0, err
+	}
+	if err := statusError("banned", response.Status); err != nil {
+		return false, 0, err
+	}
+	return response.Banned, response.Seconds, nil
+}
+
+// Peer
+// host - string; The peer's host, an IP address or an onion or i2p address.
+// id - unsigned int; The peer's ID on the network.
+// ip - unsigned int; The peer's IPv4 address in Int format, 0 for other addresses.
+// port - unsigned int; The peer's P2P port.
+// rpc_port - unsigned int; The peer's RPC port, 0 if it does not advertise one.
+// rpc_credits_per_hash - unsigned int; Credits the peer's RPC pays per hash.
+// last_seen - unsigned int; Unix time the peer was last seen, 0 for gray peers.
+// pruning_seed - unsigned int; The pruning seed of the peer, 0 if it is not pruned.
+type Peer struct {
+	Host              string `json:"host"`
+	ID                uint64 `json:"id"`
+	Ip                uint   `json:"ip"`
+	Port              uint   `json:"port"`
+	RPCPort           uint   `json:"rpc_port"`
+	RPCCreditsPerHash uint   `json:"rpc_credits_per_hash"`
+	LastSeen          uint64 `json:"last_seen"`
+	PruningSeed       uint32 `json:"pruning_seed"`
+}
+
+// PeerList
+// white_list - List of peers the daemon has connected to before.
+// gray_list - List of peers the daemon has only heard of.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type PeerList struct {
+	WhiteList []Peer `json:"white_list,omitempty"`
+	GrayList  []Peer `json:"gray_list,omitempty"`
+	Status    string `json:"status"`
+	Untrusted bool   `json:"untrusted"`
+}
+
+// GetPeerList returns the daemon's white and gray peer lists. With publicOnly
+// only peers that allow being shared are returned and with includeBlocked
+// banned peers are included.
+func (c *DaemonClient) GetPeerList(publicOnly, includeBlocked bool) (PeerList, error) {
+	var pl PeerList
+	request := struct {
+		PublicOnly     bool `json:"public_only"`
+		IncludeBlocked bool `json:"include_blocked"`
+	}{publicOnly, includeBlocked}
+	if err := c.DaemonOther("get_peer_list", &request, &pl); err != nil {
+		return pl, err
+	}
+	if err := statusError("get_peer_list", pl.Status); err != nil {
+		return pl, err
+	}
+	return pl, nil
+}
+
+// InPeers limits the number of incoming peers and returns the new limit.
+func (c *DaemonClient) InPeers(limit uint64) (uint64, error) {
+	var response struct {
+		InPeers uint64 `json:"in_peers"`
+		Status  string `json:"status"`
+	}
+	request := struct {
+		Set     bool   `json:"set"`
+		InPeers uint64 `json:"in_peers"`
+	}{true, limit}
+	if err := c.DaemonOther("in_peers", &request, &response); err != nil {
+		return 0, err
+	}
+	if err := statusError("in_peers", response.Status); err != nil {
+		return 0, err
+	}
+	return response.InPeers, nil
+}
+
+// OutPeers limits the number of outgoing peers and returns the new limit.
+func (c *DaemonClient) OutPeers(limit uint64) (uint64, error) {
+	var response struct {
+		OutPeers uint64 `json:"out_peers"`
+		Status   string `json:"status"`
+	}
+	request := struct {
+		Set      bool   `json:"set"`
+		OutPeers uint64 `json:"out_peers"`
+	}{true, limit}
+	if err := c.DaemonOther("out_peers", &request, &response); err != nil {
+		return 0, err
+	}
+	if err := statusError("out_peers", response.Status); err != nil {
+		return 0, err
+	}
+	return response.OutPeers, nil
+}
+
+// BandwidthLimit
+// limit_down - int; Download limit in kB per second.
+// limit_up - int; Upload limit in kB per second.
+type BandwidthLimit struct {
+	LimitDown int64 `json:"limit_down"`
+	LimitUp   int64 `json:"limit_up"`
+}
+
+// SetLimit sets the daemon's bandwidth limits in kB per second and returns the
+// limits now in effect. A limit of -1 resets it to the default and 0 leaves it
+// unchanged.
+func (c *DaemonClient) SetLimit(down, up int64) (BandwidthLimit, error) {
+	var response struct {
+		BandwidthLimit
+		Status string `json:"status"`
+	}
+	request := BandwidthLimit{LimitDown: down, LimitUp: up}
+	if err := c.DaemonOther("set_limit", &request, &response); err != nil {
+		return response.BandwidthLimit, err
+	}
+	if err := statusError("set_limit", response.Status); err != nil {
+		return response.BandwidthLimit, err
+	}
+	return response.BandwidthLimit, nil
+}
+
+// BanRule decides whether to ban the peer behind a connection, given the
+// height of our own chain. It returns the reason for the ban or an empty
+// string.
+type BanRule func(conn Connection, height uint64) string
+
+// AutoBanPolicy bans peers that misbehave according to the statistics of
+// GetConnections. Local peers are never banned.
+type AutoBanPolicy struct {
+	// MaxHeightLag bans peers whose chain is more than this many blocks
+	// below ours. 0 disables the check.
+	MaxHeightLag uint64
+
+	// MaxRecvIdleTime bans peers that have not sent anything for this long.
+	// 0 disables the check.
+	MaxRecvIdleTime time.Duration
+
+	// GracePeriod exempts peers connected for less than this long.
+	GracePeriod time.Duration
+
+	// Rules are checked after the ones above.
+	Rules []BanRule
+
+	// BanDuration is how long peers are banned for, at least a second since
+	// the daemon bans for whole seconds.
+	BanDuration time.Duration
+}
+
+// BanDecision is a peer the policy decided to ban.
+type BanDecision struct {
+	Connection Connection
+	Reason     string
+}
+
+// Evaluate returns the connections the policy bans, given the height of our
+// own chain. Each address is reported once.
+func (p AutoBanPolicy) Evaluate(conns []Connection, height uint64) []BanDecision {
+	var decisions []BanDecision
+	seen := map[string]bool{}
+	for _, conn := range conns {
+		if conn.Localhost || conn.LocalIp || conn.Ip == "" || seen[conn.Ip] {
+			continue
+		}
+		if time.Duration(conn.LiveTime)*time.Second < p.GracePeriod {
+			continue
+		}
+		if reason := p.reason(conn, height); reason != "" {
+			seen[conn.Ip] = true
+			decisions = append(decisions, BanDecision{Connection: conn, Reason: reason})
+		}
+	}
+	return decisions
+}
+
+func (p AutoBanPolicy) reason(conn Connection, height uint64) string {
+	if p.MaxHeightLag > 0 && uint64(conn.Height)+p.MaxHeightLag < height {
+		return fmt.Sprintf("height %d is %d blocks behind", conn.Height, height-uint64(conn.Height))
+	}
+	if p.MaxRecvIdleTime > 0 && time.Duration(conn.RecvIdleTime)*time.Second > p.MaxRecvIdleTime {
+		return fmt.Sprintf("idle for %ds", conn.RecvIdleTime)
+	}
+	for _, rule := range p.Rules {
+		if reason := rule(conn, height); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// ApplyBanPolicy evaluates the policy against the daemon's connections and
+// bans the peers it decides on. With dryRun nothing is banned. It returns the
+// decisions made.
+func (c *DaemonClient) ApplyBanPolicy(p AutoBanPolicy, dryRun bool) ([]BanDecision, error) {
+	if p.BanDuration < time.Second {
+		return nil, fmt.Errorf("ban duration %v is shorter than a second", p.BanDuration)
+	}
+	info, err := c.GetInfo()
+	if err != nil {
+		return nil, err
+	}
+	cr, err := c.GetConnections()
+	if err != nil {
+		return nil, err
+	}
+	decisions := p.Evaluate(cr.Connections, uint64(info.Height))
+	if dryRun || len(decisions) == 0 {
+		return decisions, nil
+	}
+	bans := make([]Ban, len(decisions))
+	for i, d := range decisions {
+		bans[i] = NewHostBan(d.Connection.Ip, uint(p.BanDuration/time.Second))
+	}
+	if _, err := c.SetBans(bans); err != nil {
+		return decisions, err
+	}
+	return decisions, nil
+}
@@ -0,0 +1,144 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBanHosts(t *testing.T) {
+	for _, host := range []string{
+		"", "192.0.2.51", "192.0.2.0/24", "2001:db8::1",
+		"vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion",
+		"ivikhlzvfm4aabaeuyb7bjnz4kzpwbqicsw4ddbrh2u7wdn2ir6q.b32.i2p",
+	} {
+		if err := validateBanHost(host); err != nil {
+			t.Errorf("%q: %v", host, err)
+		}
+	}
+	for _, host := range []string{"example.com", "192.0.2.0/33", "2001:db8::/32", "192.0.2"} {
+		if err := validateBanHost(host); err == nil {
+			t.Errorf("%q: expected an error", host)
+		}
+	}
+
+	c := NewDaemonClient("http://127.0.0.1:0/json_rpc")
+	if _, err := c.SetBans([]Ban{NewHostBan("example.com", 60)}); err == nil || !strings.Contains(err.Error(), "example.com") {
+		t.Errorf("got %v, want an invalid host error", err)
+	}
+
+	if got := NewBanRequest(855769280, true, 30).Address(); got != "192.0.2.51" {
+		t.Errorf("got address %s", got)
+	}
+	if got := NewHostBan("192.0.2.0/24", 30).Address(); got != "192.0.2.0/24" {
+		t.Errorf("got address %s", got)
+	}
+}
+
+func TestBannedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","result":{"banned":true,"seconds":60,"status":"Failed"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	banned, seconds, err := c.Banned("192.0.2.51")
+	if rpcErr, ok := err.(*Error); !ok || rpcErr.Code != E_SERVER {
+		t.Fatalf("got %v, want a server error", err)
+	}
+	if banned || seconds != 0 {
+		t.Errorf("got %v, %d with an error", banned, seconds)
+	}
+}
+
+func TestAutoBanPolicy(t *testing.T) {
+	conns := []Connection{
+		{Ip: "192.0.2.1", Height: 2286450, LiveTime: 600},
+		{Ip: "192.0.2.2", Height: 2200000, LiveTime: 600},
+		{Ip: "192.0.2.2", Height: 2200000, LiveTime: 600, Incoming: true},
+		{Ip: "192.0.2.3", Height: 2200000, LiveTime: 30},
+		{Ip: "127.0.0.1", Height: 0, LiveTime: 600, Localhost: true},
+		{Ip: "10.0.0.2", Height: 0, LiveTime: 600, LocalIp: true},
+		{Ip: "192.0.2.4", Height: 2286455, LiveTime: 600, RecvIdleTime: 900},
+		{Ip: "192.0.2.5", Height: 2286455, LiveTime: 600, State: "before_handshake"},
+	}
+	p := AutoBanPolicy{
+		MaxHeightLag:    1000,
+		MaxRecvIdleTime: 5 * time.Minute,
+		GracePeriod:     time.Minute,
+		Rules: []BanRule{func(conn Connection, height uint64) string {
+			if conn.State == "before_handshake" {
+				return "no handshake"
+			}
+			return ""
+		}},
+		BanDuration: time.Hour,
+	}
+	var got []string
+	for _, d := range p.Evaluate(conns, 2286455) {
+		got = append(got, d.Connection.Ip+": "+d.Reason)
+	}
+	want := []string{
+		"192.0.2.2: height 2200000 is 86455 blocks behind",
+		"192.0.2.4: idle for 900s",
+		"192.0.2.5: no handshake",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestApplyBanPolicy(t *testing.T) {
+	var bans json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result interface{}
+		switch req.Method {
+		case "get_info":
+			result = Info{Height: 2286455}
+		case "get_connections":
+			result = ConnectionResponse{Connections: []Connection{
+				{Ip: "192.0.2.1", Height: 2286455},
+				{Ip: "192.0.2.2", Height: 1000},
+			}}
+		case "setbans":
+			bans = req.Params
+			result = map[string]string{"status": "OK"}
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"id":"0","jsonrpc":"2.0","result":%s}`, data)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+	p := AutoBanPolicy{MaxHeightLag: 100, BanDuration: 2 * time.Hour}
+
+	decisions, err := c.ApplyBanPolicy(p, true)
+	if err != nil || len(decisions) != 1 || bans != nil {
+		t.Fatalf("dry run: %+v, %v, bans %s", decisions, err, bans)
+	}
+	decisions, err = c.ApplyBanPolicy(p, false)
+	if err != nil || len(decisions) != 1 {
+		t.Fatalf("got %+v, %v", decisions, err)
+	}
+	if want := `{"bans":[{"host":"192.0.2.2","ban":true,"seconds":7200}]}`; string(bans) != want {
+		t.Errorf("sent %s, want %s", bans, want)
+	}
+
+	// The daemon would treat a ban of 0 seconds as already expired.
+	bans = nil
+	for _, d := range []time.Duration{0, 500 * time.Millisecond} {
+		p.BanDuration = d
+		if decisions, err := c.ApplyBanPolicy(p, false); err == nil || bans != nil {
+			t.Errorf("ban duration %v: got %+v, %v, bans %s", d, decisions, err, bans)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package monero
+
+import (
+	"fmt"
+	"time"
+)
+
+// Protocol is the set of consensus rules of a block major version, the
+// version hard forks switch to. Later versions keep the rules of earlier
+// ones unless a feature says otherwise.
+type Protocol uint8
+
+// Feature is a consensus rule enabled by a hard fork.
+type Feature int
+
+const (
+	// TwoMinuteBlocks doubles the block target to two minutes.
+	TwoMinuteBlocks Feature = iota
+
+	// RingCT allows RingCT transactions, which hide amounts.
+	RingCT
+
+	// DynamicFee scales the fee with the median block size.
+	DynamicFee
+
+	// EnforceRingCT rejects new transactions without RingCT.
+	EnforceRingCT
+
+	// Bulletproofs replaces Borromean range proofs with bulletproofs.
+	Bulletproofs
+
+	// PerByteFee charges fees per byte of weight instead of per kB.
+	PerByteFee
+
+	// FixedRingSize requires every ring to be exactly RingSize instead of at
+	// least RingSize.
+	FixedRingSize
+
+	// SmallerBulletproofs switches to RCTTypeBulletproof2 with 8-byte
+	// encrypted amounts.
+	SmallerBulletproofs
+
+	// LongTermBlockWeight limits the growth of the median block weight.
+	LongTermBlockWeight
+
+	// RandomX switches the proof of work to RandomX.
+	RandomX
+
+	// MinTwoOutputs requires every transaction to have at least two outputs.
+	MinTwoOutputs
+
+	// CLSAG replaces MLSAG ring signatures with CLSAG, RCTTypeCLSAG.
+	CLSAG
+
+	// BulletproofsPlus replaces bulletproofs with Bulletproofs+,
+	// RCTTypeBulletproofPlus.
+	BulletproofsPlus
+
+	// ViewTags adds a view tag to each output to speed up wallet scanning.
+	ViewTags
+
+	// Scaling2021 changes the fee and block weight rules, with fees per
+	// priority.
+	Scaling2021
+)
+
+// featureVersions holds the version that enabled each feature.
+var featureVersions = [...]Protocol{
+	TwoMinuteBlocks:     2,
+	RingCT:              4,
+	DynamicFee:          4,
+	EnforceRingCT:       6,
+	Bulletproofs:        8,
+	PerByteFee:          8,
+	FixedRingSize:       8,
+	SmallerBulletproofs: 10,
+	LongTermBlockWeight: 10,
+	RandomX:             12,
+	MinTwoOutputs:       12,
+	CLSAG:               13,
+	BulletproofsPlus:    15,
+	ViewTags:            15,
+	Scaling2021:         15,
+}
+
+var featureNames = [...]string{
+	TwoMinuteBlocks:     "two minute blocks",
+	RingCT:              "RingCT",
+	DynamicFee:          "dynamic fee",
+	EnforceRingCT:       "enforced RingCT",
+	Bulletproofs:        "bulletproofs",
+	PerByteFee:          "per byte fee",
+	FixedRingSize:       "fixed ring size",
+	SmallerBulletproofs: "smaller bulletproofs",
+	LongTermBlockWeight: "long term block weight",
+	RandomX:             "RandomX",
+	MinTwoOutputs:       "minimum two outputs",
+	CLSAG:               "CLSAG",
+	BulletproofsPlus:    "Bulletproofs+",
+	ViewTags:            "view tags",
+	Scaling2021:         "2021 scaling",
+}
+
+func (f Feature) String() string {
+	if f >= 0 && int(f) < len(featureNames) {
+		return featureNames[f]
+	}
+	return fmt.Sprintf("Feature(%d)", int(f))
+}
+
+// Version returns the block major version that enabled the feature.
+func (f Feature) Version() Protocol {
+	if f < 0 || int(f) >= len(featureVersions) {
+		return 0
+	}
+	return featureVersions[f]
+}
+
+// Has reports whether the protocol includes the feature.
+func (p Protocol) Has(f Feature) bool {
+	v := f.Version()
+	return v != 0 && p >= v
+}
+
+// ringSizes holds the ring size the protocol requires from each version on.
+var ringSizes = []struct {
+	version Protocol
+	size    int
+}{
+	{15, 16},
+	{8, 11},
+	{7, 7},
+	{6, 5},
+	{2, 3},
+	{1, 1},
+}
+
+// RingSize returns the ring size of transactions under the protocol, the
+// exact size once FixedRingSize is enabled and the minimum before.
+func (p Protocol) RingSize() int {
+	for _, r := range ringSizes {
+		if p >= r.version {
+			return r.size
+		}
+	}
+	return 1
+}
+
+// BlockTarget returns the average time between blocks.
+func (p Protocol) BlockTarget() time.Duration {
+	if p.Has(TwoMinuteBlocks) {
+		return DifficultyTarget
+	}
+	return DifficultyTarget / 2
+}
+
+// Protocol returns the protocol of the block.
+func (h BlockHeader) Protocol() Protocol {
+	return Protocol(h.MajorVersion)
+}
+
+// Protocol returns the protocol the daemon currently enforces.
+func (h HardForkInfo) Protocol() Protocol {
+	return Protocol(h.Version)
+}
+
+// Protocol returns the protocol the daemon currently enforces, from
+// hard_fork_info.
+func (c *DaemonClient) Protocol() (Protocol, error) {
+	info, err := c.GetHardForkInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Protocol(), nil
+}
+
+// RingSize returns the ring size of transactions under the protocol the
+// daemon currently enforces, for the RingSize of transfer requests.
+func (c *DaemonClient) RingSize() (uint64, error) {
+	p, err := c.Protocol()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(p.RingSize()), nil
+}
+
+// HardFork is the height a network switched to a block major version.
+type HardFork struct {
+	Version Protocol
+	Height  uint64
+}
+
+// hardForks holds the hard forks of each network, from the daemon's
+// hardforks.cpp. Fakechain is the regtest schedule, which switches to the
+// latest version right after genesis.
+var hardForks = map[NetworkType][]HardFork{
+	Mainnet: {
+		{1, 1}, {2, 1009827}, {3, 1141317}, {4, 1220516}, {5, 1288616},
+		{6, 1400000}, {7, 1546000}, {8, 1685555}, {9, 1686275}, {10, 1788000},
+		{11, 1788720}, {12, 1978433}, {13, 2210000}, {14, 2210720}, {15, 2688888},
+		{16, 2689608},
+	},
+	Testnet: {
+		{1, 1}, {2, 624634}, {3, 800500}, {4, 801219}, {5, 802660},
+		{6, 971400}, {7, 1057027}, {8, 1057058}, {9, 1057778}, {10, 1154318},
+		{11, 1155038}, {12, 1308737}, {13, 1543939}, {14, 1544659}, {15, 1982800},
+		{16, 1983520},
+	},
+	Stagenet: {
+		{1, 1}, {2, 32000}, {3, 33000}, {4, 34000}, {5, 35000},
+		{6, 36000}, {7, 37000}, {8, 176456}, {9, 177176}, {10, 269000},
+		{11, 269720}, {12, 454721}, {13, 675405}, {14, 676125}, {15, 1151000},
+		{16, 1151720},
+	},
+	Fakechain: {
+		{1, 0}, {16, 1},
+	},
+}
+
+// HardForks returns the hard forks of the network, oldest first.
+func (n NetworkType) HardForks() []HardFork {
+	return append([]HardFork(nil), hardForks[n]...)
+}
+
+// ProtocolAt returns the protocol of the block at height on the network.
+func (n NetworkType) ProtocolAt(height uint64) Protocol {
+	p := Protocol(1)
+	for _, hf := range hardForks[n] {
+		if height < hf.Height {
+			break
+		}
+		p = hf.Version
+	}
+	return p
+}
+
+// ActivationHeight returns the height the network enabled the feature at.
+func (n NetworkType) ActivationHeight(f Feature) (uint64, bool) {
+	v := f.Version()
+	for _, hf := range hardForks[n] {
+		if v != 0 && hf.Version >= v {
+			return hf.Height, true
+		}
+	}
+	return 0, false
+}
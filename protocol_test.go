@@ -0,0 +1,97 @@
+package monero
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProtocol(t *testing.T) {
+	for _, c := range []struct {
+		p        Protocol
+		ringSize int
+		has      []Feature
+		hasNot   []Feature
+	}{
+		{1, 1, nil, []Feature{TwoMinuteBlocks, RingCT}},
+		{4, 3, []Feature{RingCT, DynamicFee}, []Feature{EnforceRingCT}},
+		{7, 7, []Feature{EnforceRingCT}, []Feature{Bulletproofs, FixedRingSize}},
+		{8, 11, []Feature{Bulletproofs, PerByteFee, FixedRingSize}, []Feature{SmallerBulletproofs}},
+		{14, 11, []Feature{RandomX, CLSAG}, []Feature{BulletproofsPlus, ViewTags}},
+		{16, 16, []Feature{BulletproofsPlus, ViewTags, Scaling2021}, nil},
+		{17, 16, []Feature{ViewTags}, []Feature{Feature(99)}},
+	} {
+		if got := c.p.RingSize(); got != c.ringSize {
+			t.Errorf("version %d: got ring size %d, want %d", c.p, got, c.ringSize)
+		}
+		for _, f := range c.has {
+			if !c.p.Has(f) {
+				t.Errorf("version %d: missing %s", c.p, f)
+			}
+		}
+		for _, f := range c.hasNot {
+			if c.p.Has(f) {
+				t.Errorf("version %d: has %s", c.p, f)
+			}
+		}
+	}
+	if Protocol(1).BlockTarget() != time.Minute || Protocol(2).BlockTarget() != DifficultyTarget {
+		t.Error("wrong block targets")
+	}
+	if got := (BlockHeader{MajorVersion: 13}).Protocol(); !got.Has(CLSAG) || got.Has(ViewTags) {
+		t.Errorf("got protocol %d for a version 13 block", got)
+	}
+}
+
+func TestHardForks(t *testing.T) {
+	for _, c := range []struct {
+		network NetworkType
+		height  uint64
+		want    Protocol
+	}{
+		{Mainnet, 0, 1},
+		{Mainnet, 1009826, 1},
+		{Mainnet, 1009827, 2},
+		{Mainnet, 2286454, 14},
+		{Mainnet, 2688888, 15},
+		{Mainnet, 3000000, 16},
+		{Testnet, 1982800, 15},
+		{Stagenet, 1151719, 15},
+		{Stagenet, 1151720, 16},
+		{Fakechain, 0, 1},
+		{Fakechain, 1, 16},
+	} {
+		if got := c.network.ProtocolAt(c.height); got != c.want {
+			t.Errorf("%s at %d: got version %d, want %d", c.network, c.height, got, c.want)
+		}
+	}
+	for _, n := range []NetworkType{Mainnet, Testnet, Stagenet, Fakechain} {
+		forks := n.HardForks()
+		for i := 1; i < len(forks); i++ {
+			if forks[i].Version <= forks[i-1].Version || forks[i].Height <= forks[i-1].Height {
+				t.Errorf("%s: fork %+v does not follow %+v", n, forks[i], forks[i-1])
+			}
+		}
+	}
+	if h, ok := Mainnet.ActivationHeight(ViewTags); !ok || h != 2688888 {
+		t.Errorf("got view tags at %d, %v", h, ok)
+	}
+	if h, ok := Stagenet.ActivationHeight(CLSAG); !ok || h != 675405 {
+		t.Errorf("got CLSAG at %d, %v", h, ok)
+	}
+
+	// The fixture's daemon enforces version 14, which mainnet switched to at
+	// its earliest height.
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+	p, err := c.Protocol()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != Mainnet.ProtocolAt(2210720) || p.RingSize() != 11 {
+		t.Errorf("got protocol %d", p)
+	}
+	if size, err := c.RingSize(); err != nil || size != 11 {
+		t.Errorf("got ring size %d, %v, want 11", size, err)
+	}
+}
@@ -0,0 +1,222 @@
+package monero
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AlternateChain
+// block_hash - string; The hash of the tip of the alternate chain.
+// height - unsigned int; The height of the tip of the alternate chain.
+// length - unsigned int; The number of blocks in the alternate chain after the main chain parent.
+// difficulty - unsigned int; The cumulative difficulty of the alternate chain.
+// difficulty_top64 - unsigned int; High 64 bits of the cumulative difficulty.
+// wide_difficulty - string; The cumulative difficulty as a 128 bit hex number.
+// main_chain_parent_block - string; The hash of the main chain block the alternate chain forks from.
+// block_hashes - List of the hashes of the blocks in the alternate chain, tip first.
+type AlternateChain struct {
+	BlockHash            string     `json:"block_hash"`
+	Height               uint64     `json:"height"`
+	Length               uint64     `json:"length"`
+	Difficulty           uint64     `json:"difficulty"`
+	DifficultyTop64      uint64     `json:"difficulty_top64"`
+	WideDifficulty       Difficulty `json:"wide_difficulty"`
+	MainChainParentBlock string     `json:"main_chain_parent_block"`
+	BlockHashes          []string   `json:"block_hashes"`
+}
+
+// GetAlternateChains returns the alternate chains the daemon knows about.
+func (c *DaemonClient) GetAlternateChains() ([]AlternateChain, error) {
+	var response struct {
+		Chains    []AlternateChain `json:"chains,omitempty"`
+		Status    string           `json:"status"`
+		Untrusted bool             `json:"untrusted"`
+	}
+	if err := c.Daemon("get_alternate_chains", nil, &response); err != nil {
+		return nil, err
+	}
+	if err := statusError("get_alternate_chains", response.Status); err != nil {
+		return nil, err
+	}
+	return response.Chains, nil
+}
+
+// ErrReorgTooDeep is returned by ReorgDetector.Poll if the chain changed below
+// the oldest block the detector remembers. The ReorgEvent returned with it
+// has every remembered block in Detached.
+var ErrReorgTooDeep = errors.New("reorganization deeper than the detector's depth")
+
+// ReorgEvent describes a chain reorganization.
+type ReorgEvent struct {
+	// ForkHeight and ForkHash identify the last block both chains share.
+	ForkHeight uint64
+	ForkHash   string
+
+	// Detached lists the hashes of the blocks that left the main chain and
+	// Attached those of the blocks that replaced them, both by height from
+	// ForkHeight+1 on.
+	Detached []string
+	Attached []string
+
+	// NewTip is the header of the new top block.
+	NewTip BlockHeader
+}
+
+// ReorgDetector follows the main chain and reports when blocks it has seen
+// are replaced. It remembers the hashes of the last depth blocks, deeper
+// reorganizations are reported with ErrReorgTooDeep. A ReorgDetector is not
+// safe for concurrent use.
+type ReorgDetector struct {
+	c     *DaemonClient
+	depth int
+
+	// hashes holds the main chain hashes from height base on.
+	base   uint64
+	hashes []string
+}
+
+// NewReorgDetector creates a ReorgDetector remembering depth blocks.
+func NewReorgDetector(c *DaemonClient, depth int) *ReorgDetector {
+	if depth < 1 {
+		depth = 1
+	}
+	return &ReorgDetector{c: c, depth: depth}
+}
+
+// Tip returns the height and hash of the newest block the detector has seen.
+// ok is false before the first Poll.
+func (d *ReorgDetector) Tip() (height uint64, hash string, ok bool) {
+	if len(d.hashes) == 0 {
+		return 0, "", false
+	}
+	return d.base + uint64(len(d.hashes)) - 1, d.hashes[len(d.hashes)-1], true
+}
+
+// Poll catches up with the daemon's main chain. It returns a ReorgEvent if
+// blocks it has seen were detached since the last call and nil otherwise.
+// The first call only records the current chain. An event returned along with
+// an error still happened, the error only means Poll could not catch up with
+// the new tip and the next call continues.
+func (d *ReorgDetector) Poll() (*ReorgEvent, error) {
+	last, err := d.c.GetLastBlockHeader()
+	if err != nil {
+		return nil, err
+	}
+	tip := last.BlockHeader
+	if len(d.hashes) == 0 {
+		return nil, d.reset(tip)
+	}
+
+	// Find the newest remembered block that is still on the main chain.
+	ourTip, _, _ := d.Tip()
+	fork := ourTip
+	if tip.Height < fork {
+		fork = tip.Height
+	}
+	found := false
+	for ; fork >= d.base; fork-- {
+		hash, err := d.hash(fork, tip)
+		if err != nil {
+			return nil, err
+		}
+		if hash == d.hashes[fork-d.base] {
+			found = true
+			break
+		}
+		if fork == 0 {
+			break
+		}
+	}
+	if !found {
+		event := &ReorgEvent{Detached: d.hashes, NewTip: tip}
+		if d.base > 0 {
+			event.ForkHeight = d.base - 1
+		}
+		d.hashes = nil
+		if err := d.reset(tip); err != nil {
+			return event, err
+		}
+		return event, ErrReorgTooDeep
+	}
+
+	if fork == ourTip {
+		return nil, d.extend(tip)
+	}
+	keep := fork - d.base + 1
+	event := &ReorgEvent{
+		ForkHeight: fork,
+		ForkHash:   d.hashes[keep-1],
+		Detached:   append([]string(nil), d.hashes[keep:]...),
+		NewTip:     tip,
+	}
+	d.hashes = d.hashes[:keep]
+	err = d.extend(tip)
+	start := fork + 1
+	if start < d.base {
+		start = d.base
+	}
+	if start-d.base < uint64(len(d.hashes)) {
+		event.Attached = append([]string(nil), d.hashes[start-d.base:]...)
+	}
+	return event, err
+}
+
+// reset forgets all blocks and remembers the depth blocks up to tip.
+func (d *ReorgDetector) reset(tip BlockHeader) error {
+	d.base = 0
+	if tip.Height >= uint64(d.depth) {
+		d.base = tip.Height - uint64(d.depth) + 1
+	}
+	d.hashes = nil
+	headers, err := d.c.GetBlockHeadersRange(d.base, tip.Height)
+	if err != nil {
+		return err
+	}
+	for _, header := range headers {
+		d.hashes = append(d.hashes, header.Hash)
+	}
+	if d.hashes[len(d.hashes)-1] != tip.Hash {
+		d.hashes = nil
+		return fmt.Errorf("block %d changed while reading the chain", tip.Height)
+	}
+	return nil
+}
+
+// hash returns the main chain hash at height, using tip if it is the block
+// asked for.
+func (d *ReorgDetector) hash(height uint64, tip BlockHeader) (string, error) {
+	if height == tip.Height {
+		return tip.Hash, nil
+	}
+	header, err := d.c.GetBlockHeaderByHeight(height)
+	if err != nil {
+		return "", err
+	}
+	return header.BlockHeader.Hash, nil
+}
+
+// extend appends the blocks after the remembered ones up to tip and forgets
+// the blocks older than the detector's depth.
+func (d *ReorgDetector) extend(tip BlockHeader) error {
+	for height := d.base + uint64(len(d.hashes)); height <= tip.Height; height++ {
+		header := tip
+		if height != tip.Height {
+			response, err := d.c.GetBlockHeaderByHeight(height)
+			if err != nil {
+				return err
+			}
+			header = response.BlockHeader
+		}
+		if prev := d.hashes[len(d.hashes)-1]; header.PrevHash != prev {
+			// The chain changed while catching up, the next Poll
+			// reports it.
+			return fmt.Errorf("block %d does not follow %s", height, prev)
+		}
+		d.hashes = append(d.hashes, header.Hash)
+	}
+	if extra := len(d.hashes) - d.depth; extra > 0 {
+		d.hashes = append([]string(nil), d.hashes[extra:]...)
+		d.base += uint64(extra)
+	}
+	return nil
+}
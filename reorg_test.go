@@ -0,0 +1,150 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// chainServer serves block headers of a chain that tests can reorganize.
+type chainServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	hashes []string
+}
+
+func newChainServer(t *testing.T, height int) *chainServer {
+	s := &chainServer{}
+	for h := 0; h <= height; h++ {
+		s.hashes = append(s.hashes, fmt.Sprintf("main-%d", h))
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Height      uint64 `json:"height"`
+				StartHeight uint64 `json:"start_height"`
+				EndHeight   uint64 `json:"end_height"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var result interface{}
+		switch req.Method {
+		case "getlastblockheader":
+			result = BlockHeaderResponse{BlockHeader: s.header(len(s.hashes) - 1)}
+		case "getblockheaderbyheight":
+			result = BlockHeaderResponse{BlockHeader: s.header(int(req.Params.Height))}
+		case "getblockheadersrange":
+			var headers []BlockHeader
+			for h := req.Params.StartHeight; h <= req.Params.EndHeight; h++ {
+				headers = append(headers, s.header(int(h)))
+			}
+			result = map[string]interface{}{"headers": headers}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"id":"0","jsonrpc":"2.0","result":%s}`, data)
+	}))
+	return s
+}
+
+func (s *chainServer) header(height int) BlockHeader {
+	h := BlockHeader{Height: uint64(height), Hash: s.hashes[height]}
+	if height > 0 {
+		h.PrevHash = s.hashes[height-1]
+	}
+	return h
+}
+
+// mine replaces the blocks above fork with count new blocks of branch.
+func (s *chainServer) mine(fork, count int, branch string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hashes = s.hashes[:fork+1]
+	for i := 1; i <= count; i++ {
+		s.hashes = append(s.hashes, fmt.Sprintf("%s-%d", branch, fork+i))
+	}
+}
+
+func TestReorgDetector(t *testing.T) {
+	srv := newChainServer(t, 100)
+	defer srv.Close()
+	d := NewReorgDetector(NewDaemonClient(srv.URL+"/json_rpc"), 10)
+
+	if event, err := d.Poll(); event != nil || err != nil {
+		t.Fatalf("first poll: %+v, %v", event, err)
+	}
+	if height, hash, ok := d.Tip(); !ok || height != 100 || hash != "main-100" {
+		t.Fatalf("tip %d %s %v", height, hash, ok)
+	}
+
+	// New blocks on top are no reorganization.
+	srv.mine(100, 3, "main")
+	if event, err := d.Poll(); event != nil || err != nil {
+		t.Fatalf("poll after new blocks: %+v, %v", event, err)
+	}
+
+	// Replace the top two blocks with three others.
+	srv.mine(101, 3, "alt")
+	event, err := d.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ReorgEvent{
+		ForkHeight: 101,
+		ForkHash:   "main-101",
+		Detached:   []string{"main-102", "main-103"},
+		Attached:   []string{"alt-102", "alt-103", "alt-104"},
+		NewTip:     BlockHeader{Height: 104, Hash: "alt-104", PrevHash: "alt-103"},
+	}
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("got %+v\nwant %+v", event, want)
+	}
+
+	// A shorter chain replacing the tip.
+	srv.mine(103, 0, "")
+	event, err = d.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event == nil || event.ForkHeight != 103 || !reflect.DeepEqual(event.Detached, []string{"alt-104"}) || len(event.Attached) != 0 {
+		t.Errorf("got %+v", event)
+	}
+
+	// Deeper than the detector remembers.
+	srv.mine(50, 60, "deep")
+	event, err = d.Poll()
+	if err != ErrReorgTooDeep {
+		t.Fatalf("got %v, want ErrReorgTooDeep", err)
+	}
+	if event == nil || len(event.Detached) != 9 || event.Detached[8] != "alt-103" || event.NewTip.Hash != "deep-110" {
+		t.Errorf("got %+v", event)
+	}
+	if height, hash, _ := d.Tip(); height != 110 || hash != "deep-110" {
+		t.Errorf("tip %d %s after deep reorganization", height, hash)
+	}
+	if event, err := d.Poll(); event != nil || err != nil {
+		t.Errorf("poll after deep reorganization: %+v, %v", event, err)
+	}
+}
+
+func TestGetAlternateChainsStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","result":{"status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	if chains, err := c.GetAlternateChains(); err == nil {
+		t.Errorf("got %+v, want an error for a BUSY reply", chains)
+	}
+}
@@ -0,0 +1,193 @@
+package monero
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaDrift describes the difference between a response and the struct it
+// was decoded into. Field paths are dotted, with "[]" marking array elements
+// and "*" marking map values, e.g. "block_header.reward" or "peers[].info.ip".
+type SchemaDrift struct {
+	// Method is the RPC method that returned the response.
+	Method string
+
+	// Unknown lists fields present in the response that the struct has no
+	// field for. They are silently dropped by encoding/json.
+	Unknown []string
+
+	// Missing lists struct fields that are not marked omitempty but never
+	// arrived in the response. They are left at their zero value.
+	Missing []string
+}
+
+// Detected reports whether any drift was found.
+func (d SchemaDrift) Detected() bool {
+	return len(d.Unknown) > 0 || len(d.Missing) > 0
+}
+
+// SchemaDriftHandler is called with the drift detected in a response.
+type SchemaDriftHandler func(drift SchemaDrift)
+
+// DetectSchemaDrift compares the JSON document data against the type of v
+// and reports fields unknown to v and fields of v that are missing from data.
+// Field names are matched the way encoding/json matches them.
+func DetectSchemaDrift(method string, data []byte, v interface{}) SchemaDrift {
+	drift := SchemaDrift{Method: method}
+	if v == nil {
+		return drift
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return drift
+	}
+
+	d := &driftWalker{unknown: map[string]bool{}, missing: map[string]bool{}}
+	d.walk("", doc, reflect.TypeOf(v))
+	drift.Unknown = sortedKeys(d.unknown)
+	drift.Missing = sortedKeys(d.missing)
+	return drift
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+type driftWalker struct {
+	unknown map[string]bool
+	missing map[string]bool
+}
+
+func (d *driftWalker) walk(path string, doc interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// types that decode themselves define their own schema
+	if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		seen := make([]bool, len(fields))
+		for key, value := range obj {
+			i := matchJSONField(fields, key)
+			if i < 0 {
+				d.unknown[joinPath(path, key)] = true
+				continue
+			}
+			seen[i] = true
+			d.walk(joinPath(path, key), value, fields[i].typ)
+		}
+		for i, f := range fields {
+			if !seen[i] && !f.omitEmpty {
+				d.missing[joinPath(path, f.name)] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		if arr, ok := doc.([]interface{}); ok {
+			for _, value := range arr {
+				d.walk(path+"[]", value, t.Elem())
+			}
+		}
+	case reflect.Map:
+		if obj, ok := doc.(map[string]interface{}); ok {
+			for _, value := range obj {
+				d.walk(joinPath(path, "*"), value, t.Elem())
+			}
+		}
+	}
+}
+
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields lists the fields encoding/json would decode into for the struct
+// type t, including the promoted fields of embedded structs.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(ft)...)
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		omitEmpty := false
+		for _, opt := range strings.Split(opts, ",") {
+			if strings.TrimSpace(opt) == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		fields = append(fields, jsonField{name, sf.Type, omitEmpty})
+	}
+	return fields
+}
+
+// matchJSONField returns the index of the field key decodes into, preferring
+// an exact match over a case-insensitive one, or -1 if there is none.
+func matchJSONField(fields []jsonField, key string) int {
+	for i, f := range fields {
+		if f.name == key {
+			return i
+		}
+	}
+	for i, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,119 @@
+package monero
+
+import (
+	"reflect"
+	"testing"
+)
+
+type driftHeader struct {
+	Height uint   `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+type driftStatus struct {
+	Status    string `json:"status"`
+	Untrusted bool   `json:"untrusted,omitempty"`
+}
+
+type driftReply struct {
+	driftStatus
+	*driftCredits
+	Header  driftHeader            `json:"block_header"`
+	Headers []driftHeader          `json:"headers"`
+	Peers   map[string]driftHeader `json:"peers,omitempty"`
+	Blob    []byte                 `json:"blob,omitempty"`
+	Wide    Difficulty             `json:"wide_difficulty,omitempty"`
+	Ignored string                 `json:"-"`
+	hidden  string
+}
+
+type driftCredits struct {
+	Credits uint64 `json:"credits"`
+}
+
+// driftMisspelled has the misspelled tags BlockTemplate and BlockHeader used
+// to have, which decode nothing.
+type driftMisspelled struct {
+	BlockTemplateBlob string `json:"blocktemplate_blob "`
+	Reward            uint   `json:"reward "`
+}
+
+func TestDetectSchemaDrift(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		v       interface{}
+		unknown []string
+		missing []string
+	}{
+		{
+			name: "match",
+			data: `{"status":"OK","credits":0,"block_header":{"height":1,"hash":"ab"},"headers":[]}`,
+			v:    &driftReply{},
+		},
+		{
+			name:    "unknown and missing",
+			data:    `{"status":"OK","top_hash":"","headers":null}`,
+			v:       &driftReply{},
+			unknown: []string{"top_hash"},
+			missing: []string{"block_header", "credits"},
+		},
+		{
+			name:    "nested",
+			data:    `{"status":"OK","credits":0,"block_header":{"height":1,"pow_hash":""},"headers":[]}`,
+			v:       &driftReply{},
+			unknown: []string{"block_header.pow_hash"},
+			missing: []string{"block_header.hash"},
+		},
+		{
+			name:    "slices and maps",
+			data:    `{"status":"OK","credits":0,"block_header":{"height":1,"hash":""},"headers":[{"height":1,"hash":""},{"height":2,"x":1}],"peers":{"a":{"height":1,"hash":"","y":2}}}`,
+			v:       &driftReply{},
+			unknown: []string{"headers[].x", "peers.*.y"},
+			missing: []string{"headers[].hash"},
+		},
+		{
+			name: "self decoding and ignored fields",
+			data: `{"status":"OK","credits":0,"block_header":{"height":1,"hash":""},"headers":[],"blob":"AQI=","wide_difficulty":{"anything":1}}`,
+			v:    &driftReply{},
+		},
+		{
+			name:    "case insensitive",
+			data:    `{"Status":"OK","CREDITS":0,"block_header":{"Height":1,"hash":""},"headers":[],"Ignored":"","hidden":""}`,
+			v:       &driftReply{},
+			unknown: []string{"Ignored", "hidden"},
+		},
+		{
+			name:    "misspelled tags",
+			data:    `{"blocktemplate_blob":"0e0e","reward":600000000000}`,
+			v:       &driftMisspelled{},
+			unknown: []string{"blocktemplate_blob", "reward"},
+			missing: []string{"blocktemplate_blob ", "reward "},
+		},
+		{
+			name: "invalid json",
+			data: `{"status":`,
+			v:    &driftReply{},
+		},
+		{
+			name: "no reply",
+			data: `{"status":"OK"}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			drift := DetectSchemaDrift("test", []byte(tc.data), tc.v)
+			if drift.Method != "test" {
+				t.Errorf("got method %q", drift.Method)
+			}
+			if !reflect.DeepEqual(drift.Unknown, tc.unknown) {
+				t.Errorf("got unknown fields %q, want %q", drift.Unknown, tc.unknown)
+			}
+			if !reflect.DeepEqual(drift.Missing, tc.missing) {
+				t.Errorf("got missing fields %q, want %q", drift.Missing, tc.missing)
+			}
+			if want := len(tc.unknown) > 0 || len(tc.missing) > 0; drift.Detected() != want {
+				t.Errorf("Detected() = %v, want %v", drift.Detected(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,882 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Tags of transaction inputs and outputs in the binary format.
+const (
+	txInGenTag     = 0xff
+	txInToKeyTag   = 0x02
+	txOutToKeyTag  = 0x02
+	txOutTaggedTag = 0x03
+)
+
+// Sizes of fixed size fields in the binary format.
+const (
+	keySize          = 32
+	ringSigSize      = 2 * keySize
+	ecdhAmountSize   = 8
+	viewTagSize      = 1
+	blockNonceSize   = 4
+	bulletproofNbpV1 = 4 // RCTTypeBulletproof stores nbp as a uint32
+)
+
+// ErrUnsupportedRCTType is returned when decoding or encoding a transaction
+// with a RingCT type that uses Borromean range proofs, RCTTypeFull and
+// RCTTypeSimple.
+var ErrUnsupportedRCTType = errors.New("unsupported RingCT type")
+
+// ErrPrunedTransaction is returned when the id of a pruned transaction is
+// computed without its prunable hash.
+var ErrPrunedTransaction = errors.New("transaction is pruned")
+
+// blobReader reads the fields of Monero's binary serialization.
+type blobReader struct {
+	b   []byte
+	pos int
+}
+
+var errShortBlob = errors.New("blob too short")
+
+func (r *blobReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	if n <= 0 {
+		if n == 0 {
+			return 0, errShortBlob
+		}
+		return 0, fmt.Errorf("invalid varint at offset %d", r.pos)
+	}
+	r.pos += n
+	return v, nil
+}
+
+// count reads the length of a list of items of at least size bytes each,
+// rejecting lengths the rest of the blob cannot hold.
+func (r *blobReader) count(size int) (int, error) {
+	n, err := r.varint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64((len(r.b)-r.pos)/size) {
+		return 0, errShortBlob
+	}
+	return int(n), nil
+}
+
+func (r *blobReader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.b)-r.pos < n {
+		return nil, errShortBlob
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *blobReader) hex(n int) (string, error) {
+	b, err := r.bytes(n)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (r *blobReader) keys(n int) ([]string, error) {
+	keys := make([]string, n)
+	for i := range keys {
+		var err error
+		if keys[i], err = r.hex(keySize); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// keyList reads a varint length followed by that many keys.
+func (r *blobReader) keyList() ([]string, error) {
+	n, err := r.count(keySize)
+	if err != nil {
+		return nil, err
+	}
+	return r.keys(n)
+}
+
+// blobWriter writes the fields of Monero's binary serialization.
+type blobWriter struct {
+	b []byte
+}
+
+func (w *blobWriter) varint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	w.b = append(w.b, buf[:n]...)
+}
+
+func (w *blobWriter) uint32(v uint32) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	w.b = append(w.b, buf[:]...)
+}
+
+// hex writes a hex string that must decode to exactly n bytes.
+func (w *blobWriter) hex(s string, n int) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != n {
+		return fmt.Errorf("got %d bytes of hex, want %d: %q", len(b), n, s)
+	}
+	w.b = append(w.b, b...)
+	return nil
+}
+
+func (w *blobWriter) keys(keys []string) error {
+	for _, k := range keys {
+		if err := w.hex(k, keySize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyList writes the length of keys followed by the keys.
+func (w *blobWriter) keyList(keys []string) error {
+	w.varint(uint64(len(keys)))
+	return w.keys(keys)
+}
+
+// DecodeTransaction parses a transaction in the binary format, as found in
+// TransactionEntry.AsHex.
+func DecodeTransaction(blob []byte) (Transaction, error) {
+	r := &blobReader{b: blob}
+	tx, err := decodeTransaction(r, false)
+	if err != nil {
+		return tx, err
+	}
+	if r.pos != len(blob) {
+		return tx, fmt.Errorf("%d trailing bytes after the transaction", len(blob)-r.pos)
+	}
+	return tx, nil
+}
+
+// DecodePrunedTransaction parses the prefix and RingCT base of a transaction,
+// as found in TransactionEntry.PrunedAsHex.
+func DecodePrunedTransaction(blob []byte) (Transaction, error) {
+	r := &blobReader{b: blob}
+	tx, err := decodeTransaction(r, true)
+	if err != nil {
+		return tx, err
+	}
+	if r.pos != len(blob) {
+		return tx, fmt.Errorf("%d trailing bytes after the transaction", len(blob)-r.pos)
+	}
+	return tx, nil
+}
+
+// Decode parses the binary form of the transaction. Pruned transactions have
+// no RctSigPrunable.
+func (e TransactionEntry) Decode() (Transaction, error) {
+	if h := e.Hex(); h != "" {
+		blob, err := hex.DecodeString(h)
+		if err != nil {
+			return Transaction{}, err
+		}
+		return DecodeTransaction(blob)
+	}
+	blob, err := hex.DecodeString(e.PrunedAsHex)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return DecodePrunedTransaction(blob)
+}
+
+func decodeTransaction(r *blobReader, pruned bool) (Transaction, error) {
+	var tx Transaction
+	if err := decodePrefix(r, &tx); err != nil {
+		return tx, err
+	}
+	if tx.Version == 1 {
+		if pruned {
+			return tx, nil
+		}
+		return tx, decodeRingSignatures(r, &tx)
+	}
+	rct, err := decodeRctBase(r, len(tx.Outputs))
+	if err != nil {
+		return tx, err
+	}
+	tx.RctSignatures = &rct
+	if pruned || rct.Type == RCTTypeNull {
+		return tx, nil
+	}
+	prunable, err := decodeRctPrunable(r, rct.Type, tx.Inputs)
+	if err != nil {
+		return tx, err
+	}
+	tx.RctSigPrunable = &prunable
+	return tx, nil
+}
+
+func decodePrefix(r *blobReader, tx *Transaction) error {
+	var err error
+	if tx.Version, err = r.varint(); err != nil {
+		return err
+	}
+	if tx.Version != 1 && tx.Version != 2 {
+		return fmt.Errorf("unknown transaction version %d", tx.Version)
+	}
+	if tx.UnlockTime, err = r.varint(); err != nil {
+		return err
+	}
+	n, err := r.count(2)
+	if err != nil {
+		return err
+	}
+	tx.Inputs = make([]TxInput, n)
+	for i := range tx.Inputs {
+		tag, err := r.bytes(1)
+		if err != nil {
+			return err
+		}
+		switch tag[0] {
+		case txInGenTag:
+			var gen TxInputGen
+			if gen.Height, err = r.varint(); err != nil {
+				return err
+			}
+			tx.Inputs[i].Gen = &gen
+		case txInToKeyTag:
+			var key TxInputToKey
+			if key.Amount, err = r.varint(); err != nil {
+				return err
+			}
+			offsets, err := r.count(1)
+			if err != nil {
+				return err
+			}
+			key.KeyOffsets = make([]uint64, offsets)
+			for j := range key.KeyOffsets {
+				if key.KeyOffsets[j], err = r.varint(); err != nil {
+					return err
+				}
+			}
+			if key.KeyImage, err = r.hex(keySize); err != nil {
+				return err
+			}
+			tx.Inputs[i].Key = &key
+		default:
+			return fmt.Errorf("unsupported input type %#x", tag[0])
+		}
+	}
+	if n, err = r.count(2); err != nil {
+		return err
+	}
+	tx.Outputs = make([]TxOutput, n)
+	for i := range tx.Outputs {
+		out := &tx.Outputs[i]
+		if out.Amount, err = r.varint(); err != nil {
+			return err
+		}
+		tag, err := r.bytes(1)
+		if err != nil {
+			return err
+		}
+		switch tag[0] {
+		case txOutToKeyTag:
+			if out.Target.Key, err = r.hex(keySize); err != nil {
+				return err
+			}
+		case txOutTaggedTag:
+			var tk TaggedKey
+			if tk.Key, err = r.hex(keySize); err != nil {
+				return err
+			}
+			if tk.ViewTag, err = r.hex(viewTagSize); err != nil {
+				return err
+			}
+			out.Target.TaggedKey = &tk
+		default:
+			return fmt.Errorf("unsupported output type %#x", tag[0])
+		}
+	}
+	if n, err = r.count(1); err != nil {
+		return err
+	}
+	extra, err := r.bytes(n)
+	if err != nil {
+		return err
+	}
+	tx.Extra = append(TxExtra{}, extra...)
+	return nil
+}
+
+// decodeRingSignatures reads the ring signatures of a version 1 transaction,
+// one string per input. Coinbase inputs have none.
+func decodeRingSignatures(r *blobReader, tx *Transaction) error {
+	for _, in := range tx.Inputs {
+		if in.Key == nil {
+			continue
+		}
+		sig, err := r.hex(ringSigSize * len(in.Key.KeyOffsets))
+		if err != nil {
+			return err
+		}
+		tx.Signatures = append(tx.Signatures, sig)
+	}
+	return nil
+}
+
+func decodeRctBase(r *blobReader, outputs int) (RctSignatures, error) {
+	var rct RctSignatures
+	t, err := r.bytes(1)
+	if err != nil {
+		return rct, err
+	}
+	rct.Type = t[0]
+	switch rct.Type {
+	case RCTTypeNull:
+		return rct, nil
+	case RCTTypeBulletproof, RCTTypeBulletproof2, RCTTypeCLSAG, RCTTypeBulletproofPlus:
+	default:
+		return rct, fmt.Errorf("%w %d", ErrUnsupportedRCTType, rct.Type)
+	}
+	if rct.TxnFee, err = r.varint(); err != nil {
+		return rct, err
+	}
+	rct.EcdhInfo = make([]EcdhInfo, outputs)
+	for i := range rct.EcdhInfo {
+		if rct.Type == RCTTypeBulletproof {
+			if rct.EcdhInfo[i].Mask, err = r.hex(keySize); err != nil {
+				return rct, err
+			}
+			if rct.EcdhInfo[i].Amount, err = r.hex(keySize); err != nil {
+				return rct, err
+			}
+			continue
+		}
+		if rct.EcdhInfo[i].Amount, err = r.hex(ecdhAmountSize); err != nil {
+			return rct, err
+		}
+	}
+	if rct.OutPk, err = r.keys(outputs); err != nil {
+		return rct, err
+	}
+	return rct, nil
+}
+
+func decodeRctPrunable(r *blobReader, rctType uint8, inputs []TxInput) (RctSigPrunable, error) {
+	var p RctSigPrunable
+	var err error
+	switch rctType {
+	case RCTTypeBulletproofPlus:
+		n, err := r.count(6 * keySize)
+		if err != nil {
+			return p, err
+		}
+		p.Nbp = uint64(n)
+		p.Bpp = make([]BulletproofPlus, n)
+		for i := range p.Bpp {
+			if p.Bpp[i], err = decodeBulletproofPlus(r); err != nil {
+				return p, err
+			}
+		}
+	default:
+		if rctType == RCTTypeBulletproof {
+			nbp, err := r.bytes(bulletproofNbpV1)
+			if err != nil {
+				return p, err
+			}
+			p.Nbp = uint64(binary.LittleEndian.Uint32(nbp))
+		} else if p.Nbp, err = r.varint(); err != nil {
+			return p, err
+		}
+		if p.Nbp > uint64((len(r.b)-r.pos)/(9*keySize)) {
+			return p, errShortBlob
+		}
+		p.Bp = make([]Bulletproof, p.Nbp)
+		for i := range p.Bp {
+			if p.Bp[i], err = decodeBulletproof(r); err != nil {
+				return p, err
+			}
+		}
+	}
+
+	for _, in := range inputs {
+		if in.Key == nil {
+			return p, errors.New("RingCT transaction has a coinbase input")
+		}
+		ring := len(in.Key.KeyOffsets)
+		if rctType == RCTTypeCLSAG || rctType == RCTTypeBulletproofPlus {
+			var sig CLSAGSignature
+			if sig.S, err = r.keys(ring); err != nil {
+				return p, err
+			}
+			if sig.C1, err = r.hex(keySize); err != nil {
+				return p, err
+			}
+			if sig.D, err = r.hex(keySize); err != nil {
+				return p, err
+			}
+			p.CLSAGs = append(p.CLSAGs, sig)
+			continue
+		}
+		var mg MGSignature
+		mg.Ss = make([][]string, ring)
+		for j := range mg.Ss {
+			if mg.Ss[j], err = r.keys(2); err != nil {
+				return p, err
+			}
+		}
+		if mg.Cc, err = r.hex(keySize); err != nil {
+			return p, err
+		}
+		p.MGs = append(p.MGs, mg)
+	}
+	if p.PseudoOuts, err = r.keys(len(inputs)); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func decodeBulletproof(r *blobReader) (Bulletproof, error) {
+	var bp Bulletproof
+	var err error
+	for _, f := range []*string{&bp.A, &bp.S, &bp.T1, &bp.T2, &bp.Taux, &bp.Mu} {
+		if *f, err = r.hex(keySize); err != nil {
+			return bp, err
+		}
+	}
+	if bp.L, err = r.keyList(); err != nil {
+		return bp, err
+	}
+	if bp.R, err = r.keyList(); err != nil {
+		return bp, err
+	}
+	for _, f := range []*string{&bp.AScalar, &bp.BScalar, &bp.TScalar} {
+		if *f, err = r.hex(keySize); err != nil {
+			return bp, err
+		}
+	}
+	return bp, nil
+}
+
+func decodeBulletproofPlus(r *blobReader) (BulletproofPlus, error) {
+	var bp BulletproofPlus
+	var err error
+	for _, f := range []*string{&bp.A, &bp.A1, &bp.B, &bp.R1, &bp.S1, &bp.D1} {
+		if *f, err = r.hex(keySize); err != nil {
+			return bp, err
+		}
+	}
+	if bp.L, err = r.keyList(); err != nil {
+		return bp, err
+	}
+	if bp.R, err = r.keyList(); err != nil {
+		return bp, err
+	}
+	return bp, nil
+}
+
+// MarshalBinary serializes the transaction in the binary format. Without
+// RctSigPrunable only the prefix and RingCT base are written, the pruned form.
+func (tx Transaction) MarshalBinary() ([]byte, error) {
+	prefix, base, prunable, err := tx.encodeParts()
+	if err != nil {
+		return nil, err
+	}
+	blob := append(prefix, base...)
+	return append(blob, prunable...), nil
+}
+
+// encodeParts serializes the prefix, the RingCT base and the prunable part of
+// the transaction, which are hashed separately. Version 1 transactions have
+// their ring signatures in the prunable part.
+func (tx Transaction) encodeParts() (prefix, base, prunable []byte, err error) {
+	w := &blobWriter{}
+	if err = encodePrefix(w, tx); err != nil {
+		return
+	}
+	prefix = w.b
+
+	if tx.Version == 1 {
+		w = &blobWriter{}
+		if err = encodeRingSignatures(w, tx); err != nil {
+			return
+		}
+		return prefix, nil, w.b, nil
+	}
+	if tx.RctSignatures == nil {
+		return nil, nil, nil, errors.New("RingCT transaction without rct_signatures")
+	}
+	w = &blobWriter{}
+	if err = encodeRctBase(w, *tx.RctSignatures, len(tx.Outputs)); err != nil {
+		return
+	}
+	base = w.b
+	if tx.RctSigPrunable == nil || tx.RctSignatures.Type == RCTTypeNull {
+		return prefix, base, nil, nil
+	}
+	w = &blobWriter{}
+	if err = encodeRctPrunable(w, tx.RctSignatures.Type, *tx.RctSigPrunable, tx.Inputs); err != nil {
+		return
+	}
+	return prefix, base, w.b, nil
+}
+
+func encodePrefix(w *blobWriter, tx Transaction) error {
+	w.varint(tx.Version)
+	w.varint(tx.UnlockTime)
+	w.varint(uint64(len(tx.Inputs)))
+	for _, in := range tx.Inputs {
+		switch {
+		case in.Gen != nil:
+			w.b = append(w.b, txInGenTag)
+			w.varint(in.Gen.Height)
+		case in.Key != nil:
+			w.b = append(w.b, txInToKeyTag)
+			w.varint(in.Key.Amount)
+			w.varint(uint64(len(in.Key.KeyOffsets)))
+			for _, o := range in.Key.KeyOffsets {
+				w.varint(o)
+			}
+			if err := w.hex(in.Key.KeyImage, keySize); err != nil {
+				return err
+			}
+		default:
+			return errors.New("input is neither gen nor key")
+		}
+	}
+	w.varint(uint64(len(tx.Outputs)))
+	for _, out := range tx.Outputs {
+		w.varint(out.Amount)
+		if tk := out.Target.TaggedKey; tk != nil {
+			w.b = append(w.b, txOutTaggedTag)
+			if err := w.hex(tk.Key, keySize); err != nil {
+				return err
+			}
+			if err := w.hex(tk.ViewTag, viewTagSize); err != nil {
+				return err
+			}
+			continue
+		}
+		w.b = append(w.b, txOutToKeyTag)
+		if err := w.hex(out.Target.Key, keySize); err != nil {
+			return err
+		}
+	}
+	w.varint(uint64(len(tx.Extra)))
+	w.b = append(w.b, tx.Extra...)
+	return nil
+}
+
+func encodeRingSignatures(w *blobWriter, tx Transaction) error {
+	sigs := tx.Signatures
+	for _, in := range tx.Inputs {
+		if in.Key == nil {
+			continue
+		}
+		if len(sigs) == 0 {
+			return errors.New("missing ring signature")
+		}
+		if err := w.hex(sigs[0], ringSigSize*len(in.Key.KeyOffsets)); err != nil {
+			return err
+		}
+		sigs = sigs[1:]
+	}
+	if len(sigs) > 0 {
+		return fmt.Errorf("%d ring signatures without an input", len(sigs))
+	}
+	return nil
+}
+
+func encodeRctBase(w *blobWriter, rct RctSignatures, outputs int) error {
+	w.b = append(w.b, rct.Type)
+	switch rct.Type {
+	case RCTTypeNull:
+		return nil
+	case RCTTypeBulletproof, RCTTypeBulletproof2, RCTTypeCLSAG, RCTTypeBulletproofPlus:
+	default:
+		return fmt.Errorf("%w %d", ErrUnsupportedRCTType, rct.Type)
+	}
+	if len(rct.EcdhInfo) != outputs || len(rct.OutPk) != outputs {
+		return fmt.Errorf("got %d ecdhInfo and %d outPk for %d outputs", len(rct.EcdhInfo), len(rct.OutPk), outputs)
+	}
+	w.varint(rct.TxnFee)
+	for _, e := range rct.EcdhInfo {
+		if rct.Type == RCTTypeBulletproof {
+			if err := w.hex(e.Mask, keySize); err != nil {
+				return err
+			}
+			if err := w.hex(e.Amount, keySize); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.hex(e.Amount, ecdhAmountSize); err != nil {
+			return err
+		}
+	}
+	return w.keys(rct.OutPk)
+}
+
+func encodeRctPrunable(w *blobWriter, rctType uint8, p RctSigPrunable, inputs []TxInput) error {
+	switch rctType {
+	case RCTTypeBulletproofPlus:
+		w.varint(uint64(len(p.Bpp)))
+		for _, bp := range p.Bpp {
+			if err := encodeBulletproofPlus(w, bp); err != nil {
+				return err
+			}
+		}
+	default:
+		if rctType == RCTTypeBulletproof {
+			w.uint32(uint32(len(p.Bp)))
+		} else {
+			w.varint(uint64(len(p.Bp)))
+		}
+		for _, bp := range p.Bp {
+			if err := encodeBulletproof(w, bp); err != nil {
+				return err
+			}
+		}
+	}
+
+	clsag := rctType == RCTTypeCLSAG || rctType == RCTTypeBulletproofPlus
+	if clsag && len(p.CLSAGs) != len(inputs) || !clsag && len(p.MGs) != len(inputs) {
+		return fmt.Errorf("got %d ring signatures for %d inputs", len(p.CLSAGs)+len(p.MGs), len(inputs))
+	}
+	for i, in := range inputs {
+		if in.Key == nil {
+			return errors.New("RingCT transaction has a coinbase input")
+		}
+		ring := len(in.Key.KeyOffsets)
+		if clsag {
+			sig := p.CLSAGs[i]
+			if len(sig.S) != ring {
+				return fmt.Errorf("CLSAG of input %d has %d scalars for a ring of %d", i, len(sig.S), ring)
+			}
+			if err := w.keys(sig.S); err != nil {
+				return err
+			}
+			if err := w.hex(sig.C1, keySize); err != nil {
+				return err
+			}
+			if err := w.hex(sig.D, keySize); err != nil {
+				return err
+			}
+			continue
+		}
+		mg := p.MGs[i]
+		if len(mg.Ss) != ring {
+			return fmt.Errorf("MLSAG of input %d has %d rows for a ring of %d", i, len(mg.Ss), ring)
+		}
+		for _, row := range mg.Ss {
+			if len(row) != 2 {
+				return fmt.Errorf("MLSAG of input %d has a row of %d scalars", i, len(row))
+			}
+			if err := w.keys(row); err != nil {
+				return err
+			}
+		}
+		if err := w.hex(mg.Cc, keySize); err != nil {
+			return err
+		}
+	}
+	if len(p.PseudoOuts) != len(inputs) {
+		return fmt.Errorf("got %d pseudoOuts for %d inputs", len(p.PseudoOuts), len(inputs))
+	}
+	return w.keys(p.PseudoOuts)
+}
+
+func encodeBulletproof(w *blobWriter, bp Bulletproof) error {
+	if err := w.keys([]string{bp.A, bp.S, bp.T1, bp.T2, bp.Taux, bp.Mu}); err != nil {
+		return err
+	}
+	if err := w.keyList(bp.L); err != nil {
+		return err
+	}
+	if err := w.keyList(bp.R); err != nil {
+		return err
+	}
+	return w.keys([]string{bp.AScalar, bp.BScalar, bp.TScalar})
+}
+
+func encodeBulletproofPlus(w *blobWriter, bp BulletproofPlus) error {
+	if err := w.keys([]string{bp.A, bp.A1, bp.B, bp.R1, bp.S1, bp.D1}); err != nil {
+		return err
+	}
+	if err := w.keyList(bp.L); err != nil {
+		return err
+	}
+	return w.keyList(bp.R)
+}
+
+// ID computes the hash of the transaction. Pruned RingCT transactions need
+// their prunable hash, see PrunedID.
+func (tx Transaction) ID() (string, error) {
+	if tx.Version > 1 && tx.RctSignatures != nil && tx.RctSignatures.Type != RCTTypeNull && tx.RctSigPrunable == nil {
+		return "", ErrPrunedTransaction
+	}
+	return tx.id("")
+}
+
+// PrunedID computes the hash of a pruned transaction from the hash of its
+// prunable part, TransactionEntry.PrunableHash.
+func (tx Transaction) PrunedID(prunableHash string) (string, error) {
+	tx.RctSigPrunable = nil
+	return tx.id(prunableHash)
+}
+
+func (tx Transaction) id(prunableHash string) (string, error) {
+	prefix, base, prunable, err := tx.encodeParts()
+	if err != nil {
+		return "", err
+	}
+	if tx.Version == 1 {
+		sum := keccak256(prefix, prunable)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	var ph [32]byte
+	switch {
+	case prunableHash != "":
+		b, err := hex.DecodeString(prunableHash)
+		if err != nil || len(b) != 32 {
+			return "", fmt.Errorf("invalid prunable hash %q", prunableHash)
+		}
+		copy(ph[:], b)
+	case len(prunable) > 0:
+		ph = keccak256(prunable)
+	}
+	prefixHash := keccak256(prefix)
+	baseHash := keccak256(base)
+	sum := keccak256(prefixHash[:], baseHash[:], ph[:])
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DecodedBlock is a block parsed from its binary form, Block.Blob.
+type DecodedBlock struct {
+	MajorVersion uint64      `json:"major_version"`
+	MinorVersion uint64      `json:"minor_version"`
+	Timestamp    uint64      `json:"timestamp"`
+	PrevID       string      `json:"prev_id"`
+	Nonce        uint32      `json:"nonce"`
+	MinerTx      Transaction `json:"miner_tx"`
+	TxHashes     []string    `json:"tx_hashes"`
+}
+
+// DecodeBlock parses a block in the binary format.
+func DecodeBlock(blob []byte) (DecodedBlock, error) {
+	var b DecodedBlock
+	r := &blobReader{b: blob}
+	var err error
+	if b.MajorVersion, err = r.varint(); err != nil {
+		return b, err
+	}
+	if b.MinorVersion, err = r.varint(); err != nil {
+		return b, err
+	}
+	if b.Timestamp, err = r.varint(); err != nil {
+		return b, err
+	}
+	if b.PrevID, err = r.hex(keySize); err != nil {
+		return b, err
+	}
+	nonce, err := r.bytes(blockNonceSize)
+	if err != nil {
+		return b, err
+	}
+	b.Nonce = binary.LittleEndian.Uint32(nonce)
+	if b.MinerTx, err = decodeTransaction(r, false); err != nil {
+		return b, err
+	}
+	n, err := r.count(keySize)
+	if err != nil {
+		return b, err
+	}
+	if b.TxHashes, err = r.keys(n); err != nil {
+		return b, err
+	}
+	if r.pos != len(blob) {
+		return b, fmt.Errorf("%d trailing bytes after the block", len(blob)-r.pos)
+	}
+	return b, nil
+}
+
+// Decode parses the binary form of the block.
+func (b *Block) Decode() (DecodedBlock, error) {
+	blob, err := hex.DecodeString(b.Blob)
+	if err != nil {
+		return DecodedBlock{}, err
+	}
+	return DecodeBlock(blob)
+}
+
+func (b DecodedBlock) header() ([]byte, error) {
+	w := &blobWriter{}
+	w.varint(b.MajorVersion)
+	w.varint(b.MinorVersion)
+	w.varint(b.Timestamp)
+	if err := w.hex(b.PrevID, keySize); err != nil {
+		return nil, err
+	}
+	w.uint32(b.Nonce)
+	return w.b, nil
+}
+
+// MarshalBinary serializes the block in the binary format.
+func (b DecodedBlock) MarshalBinary() ([]byte, error) {
+	blob, err := b.header()
+	if err != nil {
+		return nil, err
+	}
+	tx, err := b.MinerTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	w := &blobWriter{b: append(blob, tx...)}
+	if err := w.keyList(b.TxHashes); err != nil {
+		return nil, err
+	}
+	return w.b, nil
+}
+
+// HashingBlob returns the blob the proof of work is computed on: the header,
+// the root of the transaction tree and the number of transactions.
+func (b DecodedBlock) HashingBlob() ([]byte, error) {
+	hb, err := b.header()
+	if err != nil {
+		return nil, err
+	}
+	minerTxID, err := b.MinerTx.ID()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([][32]byte, len(b.TxHashes)+1)
+	for i, h := range append([]string{minerTxID}, b.TxHashes...) {
+		raw, err := hex.DecodeString(h)
+		if err != nil || len(raw) != 32 {
+			return nil, fmt.Errorf("invalid transaction hash %q", h)
+		}
+		copy(hashes[i][:], raw)
+	}
+	root := TreeHash(hashes)
+	w := &blobWriter{b: append(hb, root[:]...)}
+	w.varint(uint64(len(hashes)))
+	return w.b, nil
+}
+
+// ID computes the hash of the block.
+func (b DecodedBlock) ID() (string, error) {
+	hb, err := b.HashingBlob()
+	if err != nil {
+		return "", err
+	}
+	w := &blobWriter{}
+	w.varint(uint64(len(hb)))
+	sum := keccak256(w.b, hb)
+	return hex.EncodeToString(sum[:]), nil
+}
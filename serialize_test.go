@@ -0,0 +1,179 @@
+package monero
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// blobEntries returns the sample transactions of testdata/blobs and those of
+// the get_transactions fixture that are not pruned.
+func blobEntries(t *testing.T) []TransactionEntry {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "blobs", "transactions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []TransactionEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range loadTransactions(t).Txs {
+		if !e.Pruned() {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	for _, e := range blobEntries(t) {
+		blob, err := hex.DecodeString(e.AsHex)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := e.Decode()
+		if err != nil {
+			t.Errorf("%s: %v", e.TxHash, err)
+			continue
+		}
+		want, err := e.Transaction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tx, want) {
+			got, _ := json.Marshal(tx)
+			t.Errorf("%s: decoded as %s, want %s", e.TxHash, got, e.AsJSON)
+		}
+		encoded, err := tx.MarshalBinary()
+		if err != nil || hex.EncodeToString(encoded) != e.AsHex {
+			t.Errorf("%s: encoded as %x, %v", e.TxHash, encoded, err)
+		}
+		if id, err := tx.ID(); err != nil || id != e.TxHash {
+			t.Errorf("%s: got id %s, %v", e.TxHash, id, err)
+		}
+
+		for n := 0; n < len(blob); n++ {
+			if _, err := DecodeTransaction(blob[:n]); err == nil {
+				t.Fatalf("%s: no error for the first %d bytes", e.TxHash, n)
+			}
+		}
+		if _, err := DecodeTransaction(append(blob, 0)); err == nil {
+			t.Errorf("%s: no error for a trailing byte", e.TxHash)
+		}
+	}
+}
+
+func TestPrunedTransaction(t *testing.T) {
+	tr := loadTransactions(t)
+	e := tr.Txs[2]
+	if !e.Pruned() {
+		t.Fatal("expected the third transaction to be pruned")
+	}
+	tx, err := e.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.RctSigPrunable != nil {
+		t.Error("pruned transaction has prunable signatures")
+	}
+	if _, err := tx.ID(); !errors.Is(err, ErrPrunedTransaction) {
+		t.Errorf("got %v, want ErrPrunedTransaction", err)
+	}
+	if id, err := tx.PrunedID(e.PrunableHash); err != nil || id != e.TxHash {
+		t.Errorf("got id %s, %v, want %s", id, err, e.TxHash)
+	}
+	encoded, err := tx.MarshalBinary()
+	if err != nil || hex.EncodeToString(encoded) != e.PrunedAsHex {
+		t.Errorf("encoded as %x, %v", encoded, err)
+	}
+
+	// The pruned part of a full transaction decodes the same way.
+	full := tr.Txs[0]
+	ftx, err := full.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pruned, err := ftx.PrunedID(full.PrunableHash)
+	if err != nil || pruned != full.TxHash {
+		t.Errorf("got pruned id %s, %v, want %s", pruned, err, full.TxHash)
+	}
+}
+
+func TestUnsupportedRCTType(t *testing.T) {
+	e := blobEntries(t)[0]
+	tx, err := e.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.RctSignatures.Type = RCTTypeSimple
+	if _, err := tx.MarshalBinary(); !errors.Is(err, ErrUnsupportedRCTType) {
+		t.Errorf("got %v, want ErrUnsupportedRCTType", err)
+	}
+	w := &blobWriter{}
+	if err := encodePrefix(w, tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeTransaction(append(w.b, RCTTypeFull)); !errors.Is(err, ErrUnsupportedRCTType) {
+		t.Errorf("got %v, want ErrUnsupportedRCTType", err)
+	}
+}
+
+func TestBlockRoundTrip(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", "daemon", "getblock.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Result Block `json:"result"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	b := fixture.Result
+	decoded, err := b.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want DecodedBlock
+	if err := json.Unmarshal([]byte(b.Json), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		got, _ := json.Marshal(decoded)
+		t.Errorf("decoded as %s, want %s", got, b.Json)
+	}
+	blob, err := decoded.MarshalBinary()
+	if err != nil || hex.EncodeToString(blob) != b.Blob {
+		t.Errorf("encoded as %x, %v", blob, err)
+	}
+	if id, err := decoded.ID(); err != nil || id != b.BlockHeader.Hash {
+		t.Errorf("got block id %s, %v, want %s", id, err, b.BlockHeader.Hash)
+	}
+	if id, err := decoded.MinerTx.ID(); err != nil || id != b.MinerTxHash {
+		t.Errorf("got miner tx id %s, %v, want %s", id, err, b.MinerTxHash)
+	}
+	for n := 0; n < len(blob); n++ {
+		if _, err := DecodeBlock(blob[:n]); err == nil {
+			t.Fatalf("no error for the first %d bytes", n)
+		}
+	}
+}
+
+func TestGenesisBlock(t *testing.T) {
+	tx, err := hex.DecodeString(genesisTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	miner, err := DecodeTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := DecodedBlock{MajorVersion: 1, PrevID: hex.EncodeToString(make([]byte, 32)), Nonce: 10000, MinerTx: miner}
+	if id, err := b.ID(); err != nil || id != "418015bb9ae982a1975da7d79277c2705727a56894ba0fb246adaabb1f4632e3" {
+		t.Errorf("got genesis block id %s, %v", id, err)
+	}
+}
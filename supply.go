@@ -0,0 +1,184 @@
+package monero
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	// MoneySupply is the amount of atomic units the main emission curve
+	// converges to, before tail emission.
+	MoneySupply = math.MaxUint64
+
+	// TailEmissionReward is the minimum base reward of a two minute block,
+	// 0.6 XMR, paid since 2022.
+	TailEmissionReward = 600000000000
+
+	// emissionSpeedFactor is the emission speed factor for one minute
+	// blocks, blocks of major version 2 and later take two minutes.
+	emissionSpeedFactor = 20
+)
+
+// CoinbaseTxSum
+// emission_amount - unsigned int; Low 64 bits of the amount of coins minted in the range.
+// emission_amount_top64 - unsigned int; High 64 bits of the amount of coins minted.
+// wide_emission_amount - string; The amount of coins minted as a 128 bit hex number.
+// fee_amount - unsigned int; Low 64 bits of the fees paid in the range.
+// fee_amount_top64 - unsigned int; High 64 bits of the fees paid.
+// wide_fee_amount - string; The fees paid as a 128 bit hex number.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type CoinbaseTxSum struct {
+	EmissionAmount      uint64 `json:"emission_amount"`
+	EmissionAmountTop64 uint64 `json:"emission_amount_top64"`
+	WideEmissionAmount  string `json:"wide_emission_amount"`
+	FeeAmount           uint64 `json:"fee_amount"`
+	FeeAmountTop64      uint64 `json:"fee_amount_top64"`
+	WideFeeAmount       string `json:"wide_fee_amount"`
+	Status              string `json:"status"`
+	Untrusted           bool   `json:"untrusted"`
+}
+
+// Emission returns the full 128 bit amount of coins minted.
+func (s CoinbaseTxSum) Emission() *big.Int {
+	return wideAmount(s.WideEmissionAmount, s.EmissionAmountTop64, s.EmissionAmount)
+}
+
+// Fees returns the full 128 bit amount of fees paid.
+func (s CoinbaseTxSum) Fees() *big.Int {
+	return wideAmount(s.WideFeeAmount, s.FeeAmountTop64, s.FeeAmount)
+}
+
+// wideAmount returns the 128 bit value of a wide hex string, or of its top
+// and low 64 bits for daemons that do not send the hex string.
+func wideAmount(wide string, top64, low uint64) *big.Int {
+	if v, err := parseWideHex(wide); err == nil {
+		return v
+	}
+	v := new(big.Int).SetUint64(top64)
+	v.Lsh(v, 64)
+	return v.Or(v, new(big.Int).SetUint64(low))
+}
+
+// parseWideHex parses the 0x prefixed hex numbers the daemon uses for values
+// that can exceed 64 bits.
+func parseWideHex(s string) (*big.Int, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	v, ok := new(big.Int).SetString(digits, 16)
+	if !ok || digits == "" || v.Sign() < 0 || digits[0] == '+' {
+		return nil, fmt.Errorf("invalid wide hex number %q", s)
+	}
+	return v, nil
+}
+
+// BaseBlockReward returns the reward of a block by the emission curve, before
+// any penalty for blocks above the median weight and without fees.
+// alreadyGenerated is the amount of coins minted before the block, saturated
+// at MoneySupply the way the daemon does.
+func BaseBlockReward(alreadyGenerated uint64, majorVersion uint) uint64 {
+	targetMinutes := uint64(2)
+	if !Protocol(majorVersion).Has(TwoMinuteBlocks) {
+		targetMinutes = 1
+	}
+	reward := (MoneySupply - alreadyGenerated) >> (emissionSpeedFactor - (targetMinutes - 1))
+	if tail := TailEmissionReward / 2 * targetMinutes; reward < tail {
+		reward = tail
+	}
+	return reward
+}
+
+// saturate returns v capped at MoneySupply.
+func saturate(v *big.Int) uint64 {
+	if !v.IsUint64() {
+		return MoneySupply
+	}
+	return v.Uint64()
+}
+
+// RewardCheck is the result of comparing a block's reward against the
+// emission curve.
+type RewardCheck struct {
+	Height uint64
+
+	// Reward is the reward of the block header, base reward plus fees.
+	Reward uint64
+
+	// Expected is the base reward by the emission curve and Fees the fees
+	// of the block's transactions.
+	Expected uint64
+	Fees     uint64
+
+	// Penalty is the part of the expected reward the miner did not claim,
+	// usually because the block was larger than the median.
+	Penalty uint64
+}
+
+// CheckBlockReward compares the reward of header against the emission curve.
+// It returns an error if the block claims more than the base reward plus
+// fees.
+func CheckBlockReward(header BlockHeader, alreadyGenerated, fees uint64) (RewardCheck, error) {
+	rc := RewardCheck{
+		Height:   header.Height,
+		Reward:   header.Reward,
+		Expected: BaseBlockReward(alreadyGenerated, header.MajorVersion),
+		Fees:     fees,
+	}
+	if rc.Reward > rc.Expected+rc.Fees {
+		return rc, fmt.Errorf("block %d: reward %d exceeds base reward %d plus fees %d", rc.Height, rc.Reward, rc.Expected, rc.Fees)
+	}
+	rc.Penalty = rc.Expected + rc.Fees - rc.Reward
+	return rc, nil
+}
+
+// GetCoinbaseTxSum returns the coins minted and fees paid in count blocks from
+// height on.
+func (c *DaemonClient) GetCoinbaseTxSum(height, count uint64) (CoinbaseTxSum, error) {
+	var cs CoinbaseTxSum
+	request := struct {
+		Height uint64 `json:"height"`
+		Count  uint64 `json:"count"`
+	}{height, count}
+	if err := c.Daemon("get_coinbase_tx_sum", &request, &cs); err != nil {
+		return cs, err
+	}
+	if err := statusError("get_coinbase_tx_sum", cs.Status); err != nil {
+		return cs, err
+	}
+	return cs, nil
+}
+
+// CirculatingSupply returns the amount of coins minted up to and including
+// the block at height. The daemon walks the whole chain to answer, which can
+// take a while.
+func (c *DaemonClient) CirculatingSupply(height uint64) (*big.Int, error) {
+	cs, err := c.GetCoinbaseTxSum(0, height+1)
+	if err != nil {
+		return nil, err
+	}
+	return cs.Emission(), nil
+}
+
+// VerifyBlockReward checks the reward of the block at height against the
+// emission curve, see CheckBlockReward. Like CirculatingSupply it needs the
+// daemon to walk the chain up to height.
+func (c *DaemonClient) VerifyBlockReward(height uint64) (RewardCheck, error) {
+	var alreadyGenerated uint64
+	if height > 0 {
+		before, err := c.GetCoinbaseTxSum(0, height)
+		if err != nil {
+			return RewardCheck{}, err
+		}
+		alreadyGenerated = saturate(before.Emission())
+	}
+	block, err := c.GetCoinbaseTxSum(height, 1)
+	if err != nil {
+		return RewardCheck{}, err
+	}
+	header, err := c.GetBlockHeaderByHeight(height)
+	if err != nil {
+		return RewardCheck{}, err
+	}
+	return CheckBlockReward(header.BlockHeader, alreadyGenerated, saturate(block.Fees()))
+}
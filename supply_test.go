@@ -0,0 +1,125 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCoinbaseTxSumWide(t *testing.T) {
+	want, _ := new(big.Int).SetString("18620104835912345678", 10)
+	tests := []CoinbaseTxSum{
+		{EmissionAmount: 173360762202794062, EmissionAmountTop64: 1, WideEmissionAmount: "0x10267e6b1d916a84e"},
+		{EmissionAmount: 173360762202794062, EmissionAmountTop64: 1},
+		{EmissionAmount: 173360762202794062, EmissionAmountTop64: 1, WideEmissionAmount: "bogus"},
+	}
+	for _, s := range tests {
+		if got := s.Emission(); got.Cmp(want) != 0 {
+			t.Errorf("%+v: got %v, want %v", s, got, want)
+		}
+	}
+	if got := saturate(want); got != MoneySupply {
+		t.Errorf("saturate: got %d", got)
+	}
+
+	for _, s := range []string{"", "0x", "0x-1", "0x+1", "0xg"} {
+		if _, err := parseWideHex(s); err == nil {
+			t.Errorf("parseWideHex(%q): expected an error", s)
+		}
+	}
+}
+
+func TestBaseBlockReward(t *testing.T) {
+	tests := []struct {
+		generated    uint64
+		majorVersion uint
+		want         uint64
+	}{
+		{0, 1, 17592186044415},
+		{0, 2, 35184372088831},
+		{17827431223561551871, 14, 1181245518013},
+		{MoneySupply - 1, 14, TailEmissionReward},
+		{MoneySupply, 16, TailEmissionReward},
+		{MoneySupply, 1, TailEmissionReward / 2},
+	}
+	for _, tc := range tests {
+		if got := BaseBlockReward(tc.generated, tc.majorVersion); got != tc.want {
+			t.Errorf("BaseBlockReward(%d, %d) = %d, want %d", tc.generated, tc.majorVersion, got, tc.want)
+		}
+	}
+}
+
+func TestCheckBlockReward(t *testing.T) {
+	header := BlockHeader{Height: 2286454, MajorVersion: 14, Reward: 1181337498013}
+	const generated = 17827431223561551871
+
+	rc, err := CheckBlockReward(header, generated, 91980000)
+	if err != nil || rc.Penalty != 0 {
+		t.Errorf("got %+v, %v", rc, err)
+	}
+	rc, err = CheckBlockReward(header, generated, 91990000)
+	if err != nil || rc.Penalty != 10000 {
+		t.Errorf("got %+v, %v", rc, err)
+	}
+	if _, err := CheckBlockReward(header, generated, 91970000); err == nil {
+		t.Error("expected an error for a reward above the emission curve")
+	}
+}
+
+func TestVerifyBlockReward(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+			Params struct {
+				Height uint64 `json:"height"`
+				Count  uint64 `json:"count"`
+			} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		var result interface{}
+		switch {
+		case req.Method == "getblockheaderbyheight":
+			result = BlockHeaderResponse{
+				BlockHeader: BlockHeader{Height: 2286454, MajorVersion: 14, Reward: 1181337498013},
+				Status:      "OK",
+			}
+		case req.Params.Height == 0 && req.Params.Count == 2286454:
+			result = CoinbaseTxSum{EmissionAmount: 17827431223561551871, WideEmissionAmount: "0xf767c23e7a17ffff", Status: "OK"}
+		case req.Params.Height == 2286454 && req.Params.Count == 1:
+			result = CoinbaseTxSum{EmissionAmount: 1181245518013, FeeAmount: 91980000, WideFeeAmount: "0x57b80e0", Status: "OK"}
+		default:
+			t.Errorf("unexpected request %+v", req)
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"id":"0","jsonrpc":"2.0","result":%s}`, data)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	rc, err := c.VerifyBlockReward(2286454)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RewardCheck{Height: 2286454, Reward: 1181337498013, Expected: 1181245518013, Fees: 91980000}
+	if rc != want {
+		t.Errorf("got %+v, want %+v", rc, want)
+	}
+}
+
+func TestCoinbaseTxSumStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"0","jsonrpc":"2.0","result":{"emission_amount":0,"fee_amount":0,"status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	if _, err := c.GetCoinbaseTxSum(0, 10); err == nil {
+		t.Error("GetCoinbaseTxSum: expected an error for a BUSY reply")
+	}
+	if _, err := c.VerifyBlockReward(10); err == nil {
+		t.Error("VerifyBlockReward: expected an error for a BUSY reply")
+	}
+}
@@ -0,0 +1,164 @@
+package monero
+
+import (
+	"errors"
+	"time"
+)
+
+// SyncInfo
+// height - unsigned int; Current length of the daemon's chain.
+// target_height - unsigned int; The chain height the daemon is syncing to, 0 if it is not syncing.
+// next_needed_pruning_seed - unsigned int; The pruning seed of the blocks the daemon needs next.
+// overview - string; Overview of the spans being downloaded, one character per span.
+// peers - List of the peers the daemon syncs from, see SyncPeer.
+// spans - List of the block spans being downloaded, see SyncSpan.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type SyncInfo struct {
+	Height                uint64     `json:"height"`
+	TargetHeight          uint64     `json:"target_height"`
+	NextNeededPruningSeed uint32     `json:"next_needed_pruning_seed"`
+	Overview              string     `json:"overview"`
+	Peers                 []SyncPeer `json:"peers,omitempty"`
+	Spans                 []SyncSpan `json:"spans,omitempty"`
+	Status                string     `json:"status"`
+	Untrusted             bool       `json:"untrusted"`
+}
+
+// SyncPeer
+// info - The connection to the peer, including its chain height.
+type SyncPeer struct {
+	Info Connection `json:"info"`
+}
+
+// SyncSpan
+// connection_id - string; The connection the span is downloaded from.
+// start_block_height - unsigned int; Height of the first block in the span.
+// nblocks - unsigned int; Number of blocks in the span.
+// size - unsigned int; Size of the span in bytes, 0 while it is downloading.
+// rate - unsigned int; Download rate of the span in bytes per second.
+// speed - unsigned int; Download speed relative to the other connections, in percent.
+// remote_address - string; Address of the peer.
+type SyncSpan struct {
+	ConnectionID     string `json:"connection_id"`
+	StartBlockHeight uint64 `json:"start_block_height"`
+	NBlocks          uint64 `json:"nblocks"`
+	Size             uint64 `json:"size"`
+	Rate             uint64 `json:"rate"`
+	Speed            uint64 `json:"speed"`
+	RemoteAddress    string `json:"remote_address"`
+}
+
+// SyncInfo returns the daemon's synchronization state and the peers it
+// syncs from.
+func (c *DaemonClient) SyncInfo() (SyncInfo, error) {
+	var si SyncInfo
+	if err := c.Daemon("sync_info", nil, &si); err != nil {
+		return si, err
+	}
+	if err := statusError("sync_info", si.Status); err != nil {
+		return si, err
+	}
+	return si, nil
+}
+
+// SyncStatus summarizes how far a daemon is from the network's chain.
+type SyncStatus struct {
+	Height       uint64
+	TargetHeight uint64
+
+	// Synced is true once the daemon considers itself synchronized and has
+	// reached the target height.
+	Synced bool
+
+	// Percent is the share of the target height the daemon has, 0 to 100.
+	Percent float64
+
+	// BlocksPerSecond is the sync speed since the previous status, 0 for
+	// the first one. ETA is the time left at that speed, 0 if unknown.
+	BlocksPerSecond float64
+	ETA             time.Duration
+}
+
+// SyncMonitor reports the sync progress of a daemon. It combines get_info and
+// sync_info and measures the speed between calls to Status.
+type SyncMonitor struct {
+	c   *DaemonClient
+	now func() time.Time
+
+	last     time.Time
+	lastSeen uint64
+}
+
+// NewSyncMonitor creates a SyncMonitor for the daemon.
+func NewSyncMonitor(c *DaemonClient) *SyncMonitor {
+	return &SyncMonitor{c: c, now: time.Now}
+}
+
+// Status returns the current sync status.
+func (m *SyncMonitor) Status() (SyncStatus, error) {
+	info, err := m.c.GetInfo()
+	if err != nil {
+		return SyncStatus{}, err
+	}
+	si, err := m.c.SyncInfo()
+	if err != nil {
+		return SyncStatus{}, err
+	}
+	now := m.now()
+
+	s := SyncStatus{Height: uint64(info.Height), TargetHeight: uint64(info.TargetHeight)}
+	if si.Height > s.Height {
+		s.Height = si.Height
+	}
+	if si.TargetHeight > s.TargetHeight {
+		s.TargetHeight = si.TargetHeight
+	}
+	if s.TargetHeight < s.Height {
+		s.TargetHeight = s.Height
+	}
+	s.Synced = info.Synchronized && !info.BusySyncing && s.Height >= s.TargetHeight
+	s.Percent = 100
+	if s.TargetHeight > 0 && !s.Synced {
+		s.Percent = 100 * float64(s.Height) / float64(s.TargetHeight)
+	}
+
+	if !m.last.IsZero() && now.After(m.last) && s.Height >= m.lastSeen {
+		s.BlocksPerSecond = float64(s.Height-m.lastSeen) / now.Sub(m.last).Seconds()
+	}
+	if !s.Synced && s.BlocksPerSecond > 0 {
+		s.ETA = time.Duration(float64(s.TargetHeight-s.Height) / s.BlocksPerSecond * float64(time.Second))
+	}
+	m.last, m.lastSeen = now, s.Height
+	return s, nil
+}
+
+// ErrSyncTimeout is returned by SyncMonitor.WaitSynced if the daemon did not
+// sync in time.
+var ErrSyncTimeout = errors.New("daemon did not sync in time")
+
+// WaitSynced polls the daemon every interval until it is synced or timeout has
+// passed, calling progress, if not nil, with every status. A timeout of 0
+// waits forever. Errors talking to the daemon are returned right away.
+func (m *SyncMonitor) WaitSynced(interval, timeout time.Duration, progress func(SyncStatus)) (SyncStatus, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = m.now().Add(timeout)
+	}
+	for {
+		s, err := m.Status()
+		if err != nil {
+			return s, err
+		}
+		if progress != nil {
+			progress(s)
+		}
+		if s.Synced {
+			return s, nil
+		}
+		if !deadline.IsZero() && !m.now().Add(interval).Before(deadline) {
+			return s, ErrSyncTimeout
+		}
+		time.Sleep(interval)
+	}
+}
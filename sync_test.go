@@ -0,0 +1,133 @@
+package monero
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncServer answers get_info and sync_info for a daemon at height syncing to
+// target. sync_info replies carry syncStatus if it is set.
+type syncServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	height       uint64
+	target       uint64
+	synchronized bool
+	syncStatus   string
+}
+
+func newSyncServer(t *testing.T) *syncServer {
+	s := &syncServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var result interface{}
+		switch req.Method {
+		case "get_info":
+			result = Info{Height: uint(s.height), TargetHeight: uint(s.target), Synchronized: s.synchronized, BusySyncing: !s.synchronized}
+		case "sync_info":
+			target := s.target
+			if s.synchronized {
+				target = 0
+			}
+			result = SyncInfo{Height: s.height, TargetHeight: target, Status: s.syncStatus}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+		data, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"id":"0","jsonrpc":"2.0","result":%s}`, data)
+	}))
+	return s
+}
+
+func (s *syncServer) set(height, target uint64, synchronized bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.height, s.target, s.synchronized = height, target, synchronized
+}
+
+func TestSyncMonitor(t *testing.T) {
+	srv := newSyncServer(t)
+	defer srv.Close()
+	m := NewSyncMonitor(NewDaemonClient(srv.URL + "/json_rpc"))
+	now := time.Unix(1612088597, 0)
+	m.now = func() time.Time { return now }
+
+	srv.set(1000000, 2000000, false)
+	s, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Synced || s.Percent != 50 || s.BlocksPerSecond != 0 || s.ETA != 0 {
+		t.Errorf("first status %+v", s)
+	}
+
+	now = now.Add(10 * time.Second)
+	srv.set(1001000, 2000000, false)
+	s, err = m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.BlocksPerSecond != 100 || s.ETA != 9990*time.Second || math.Abs(s.Percent-50.05) > 1e-9 {
+		t.Errorf("second status %+v", s)
+	}
+
+	now = now.Add(10 * time.Second)
+	srv.set(2000001, 0, true)
+	s, err = m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Synced || s.Percent != 100 || s.ETA != 0 || s.TargetHeight != 2000001 {
+		t.Errorf("synced status %+v", s)
+	}
+}
+
+func TestSyncMonitorStatusError(t *testing.T) {
+	srv := newSyncServer(t)
+	defer srv.Close()
+	m := NewSyncMonitor(NewDaemonClient(srv.URL + "/json_rpc"))
+
+	srv.set(1000000, 2000000, false)
+	srv.mu.Lock()
+	srv.syncStatus = "BUSY"
+	srv.mu.Unlock()
+	if s, err := m.Status(); err == nil {
+		t.Errorf("got %+v, want an error for a BUSY sync_info reply", s)
+	}
+}
+
+func TestWaitSynced(t *testing.T) {
+	srv := newSyncServer(t)
+	defer srv.Close()
+	m := NewSyncMonitor(NewDaemonClient(srv.URL + "/json_rpc"))
+
+	srv.set(10, 20, false)
+	calls := 0
+	s, err := m.WaitSynced(time.Millisecond, 0, func(s SyncStatus) {
+		calls++
+		if calls == 3 {
+			srv.set(20, 20, true)
+		}
+	})
+	if err != nil || !s.Synced || calls != 4 {
+		t.Errorf("got %+v, %v after %d calls", s, err, calls)
+	}
+
+	srv.set(10, 20, false)
+	if _, err := m.WaitSynced(time.Millisecond, 20*time.Millisecond, nil); err != ErrSyncTimeout {
+		t.Errorf("got %v, want ErrSyncTimeout", err)
+	}
+}
@@ -0,0 +1,419 @@
+package monero
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// RingCT signature types, as found in RctSignatures.Type.
+const (
+	RCTTypeNull            = 0
+	RCTTypeFull            = 1
+	RCTTypeSimple          = 2
+	RCTTypeBulletproof     = 3
+	RCTTypeBulletproof2    = 4
+	RCTTypeCLSAG           = 5
+	RCTTypeBulletproofPlus = 6
+)
+
+// TransactionsResponse
+// txs - List of the transactions found, see TransactionEntry.
+// missed_tx - List of transaction hashes the daemon does not know about.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type TransactionsResponse struct {
+	Txs       []TransactionEntry `json:"txs"`
+	MissedTx  []string           `json:"missed_tx,omitempty"`
+	Status    string             `json:"status"`
+	Untrusted bool               `json:"untrusted"`
+}
+
+// TransactionEntry
+// tx_hash - string; Transaction hash.
+// as_hex - string; Full transaction information as a hex string. Empty if the transaction was pruned or split was requested.
+// pruned_as_hex - string; The pruned part of the transaction (prefix and RingCT base) as a hex string.
+// prunable_as_hex - string; The prunable part of the transaction as a hex string. Only set if split was requested.
+// prunable_hash - string; Hash of the prunable part, needed to compute the hash of a pruned transaction.
+// as_json - json string; The transaction decoded as JSON, if decode_as_json was requested. See Transaction.
+// block_height - unsigned int; Block height the transaction was included in. 0 for transactions in the pool.
+// block_timestamp - unsigned int; Timestamp of the block the transaction was included in.
+// confirmations - unsigned int; Number of blocks mined on top of the transaction's block.
+// double_spend_seen - boolean; States if the transaction is a double spend.
+// in_pool - boolean; States if the transaction is in the pool rather than in a block.
+// output_indices - List of global output indices of the transaction's outputs. Empty for pool transactions.
+// relayed - boolean; States if a pool transaction has been relayed.
+// received_timestamp - unsigned int; Time a pool transaction was received by the node.
+type TransactionEntry struct {
+	TxHash            string   `json:"tx_hash"`
+	AsHex             string   `json:"as_hex"`
+	PrunedAsHex       string   `json:"pruned_as_hex"`
+	PrunableAsHex     string   `json:"prunable_as_hex"`
+	PrunableHash      string   `json:"prunable_hash"`
+	AsJSON            string   `json:"as_json"`
+	BlockHeight       uint64   `json:"block_height"`
+	BlockTimestamp    uint64   `json:"block_timestamp"`
+	Confirmations     uint64   `json:"confirmations"`
+	DoubleSpendSeen   bool     `json:"double_spend_seen"`
+	InPool            bool     `json:"in_pool"`
+	OutputIndices     []uint64 `json:"output_indices"`
+	Relayed           bool     `json:"relayed,omitempty"`
+	ReceivedTimestamp uint64   `json:"received_timestamp,omitempty"`
+}
+
+// Pruned reports whether the daemon only returned the pruned part of the
+// transaction, which is the case for old transactions on a pruned node or
+// when pruning was requested.
+func (e TransactionEntry) Pruned() bool {
+	return e.AsHex == "" && e.PrunableAsHex == "" && e.PrunedAsHex != ""
+}
+
+// Hex returns the full transaction as a hex string, joining the pruned and
+// prunable parts if split was requested. It returns an empty string if the
+// transaction is pruned.
+func (e TransactionEntry) Hex() string {
+	if e.AsHex != "" {
+		return e.AsHex
+	}
+	if e.PrunableAsHex == "" {
+		return ""
+	}
+	return e.PrunedAsHex + e.PrunableAsHex
+}
+
+// ErrNotDecoded is returned when a transaction was fetched without
+// decode_as_json.
+var ErrNotDecoded = errors.New("transaction was not decoded as json")
+
+// Transaction parses the JSON form of the transaction. Pruned transactions
+// have no RctSigPrunable.
+func (e TransactionEntry) Transaction() (Transaction, error) {
+	var tx Transaction
+	if e.AsJSON == "" {
+		return tx, ErrNotDecoded
+	}
+	if err := json.Unmarshal([]byte(e.AsJSON), &tx); err != nil {
+		return tx, err
+	}
+	return tx, nil
+}
+
+// Transaction
+// version - Transaction version number, 2 for RingCT transactions.
+// unlock_time - Block height or timestamp until which the outputs are locked.
+// vin - List of transaction inputs, see TxInput.
+// vout - List of transaction outputs, see TxOutput.
+// extra - Extra data, e.g. the transaction public key and payment id.
+// signatures - Ring signatures of version 1 transactions.
+// rct_signatures - RingCT signature base, see RctSignatures.
+// rctsig_prunable - Prunable RingCT proofs, see RctSigPrunable. Missing for pruned transactions.
+type Transaction struct {
+	Version        uint64          `json:"version"`
+	UnlockTime     uint64          `json:"unlock_time"`
+	Inputs         []TxInput       `json:"vin"`
+	Outputs        []TxOutput      `json:"vout"`
+	Extra          TxExtra         `json:"extra"`
+	Signatures     []string        `json:"signatures,omitempty"`
+	RctSignatures  *RctSignatures  `json:"rct_signatures,omitempty"`
+	RctSigPrunable *RctSigPrunable `json:"rctsig_prunable,omitempty"`
+}
+
+// TxInput is either a coinbase ("gen") or a key input.
+type TxInput struct {
+	Gen *TxInputGen   `json:"gen,omitempty"`
+	Key *TxInputToKey `json:"key,omitempty"`
+}
+
+// TxInputGen
+// height - The height of the block the coinbase transaction is in.
+type TxInputGen struct {
+	Height uint64 `json:"height"`
+}
+
+// TxInputToKey
+// amount - The amount of the input, 0 for RingCT inputs.
+// key_offsets - Relative global indices of the ring members.
+// k_image - The key image of the spent output.
+type TxInputToKey struct {
+	Amount     uint64   `json:"amount"`
+	KeyOffsets []uint64 `json:"key_offsets"`
+	KeyImage   string   `json:"k_image"`
+}
+
+// RingMembers converts the relative key offsets into absolute global output
+// indices.
+func (in TxInputToKey) RingMembers() []uint64 {
+	members := make([]uint64, len(in.KeyOffsets))
+	var index uint64
+	for i, offset := range in.KeyOffsets {
+		index += offset
+		members[i] = index
+	}
+	return members
+}
+
+// TxOutput
+// amount - The amount of the output, 0 for RingCT outputs.
+// target - The one-time public key the output is sent to.
+type TxOutput struct {
+	Amount uint64         `json:"amount"`
+	Target TxOutputTarget `json:"target"`
+}
+
+// TxOutputTarget holds a plain key before view tags (hard fork 15) and a
+// tagged key after.
+type TxOutputTarget struct {
+	Key       string     `json:"key,omitempty"`
+	TaggedKey *TaggedKey `json:"tagged_key,omitempty"`
+}
+
+// PublicKey returns the one-time public key of the output.
+func (t TxOutputTarget) PublicKey() string {
+	if t.TaggedKey != nil {
+		return t.TaggedKey.Key
+	}
+	return t.Key
+}
+
+// TaggedKey
+// key - The one-time public key of the output.
+// view_tag - string; One byte view tag as hex.
+type TaggedKey struct {
+	Key     string `json:"key"`
+	ViewTag string `json:"view_tag"`
+}
+
+// RctSignatures
+// type - RingCT type, see the RCTType constants.
+// txnFee - The transaction fee in atomic units.
+// ecdhInfo - Encrypted amounts (and masks before RCTTypeBulletproof2) of the outputs.
+// outPk - Output commitments.
+type RctSignatures struct {
+	Type     uint8      `json:"type"`
+	TxnFee   uint64     `json:"txnFee,omitempty"`
+	EcdhInfo []EcdhInfo `json:"ecdhInfo,omitempty"`
+	OutPk    []string   `json:"outPk,omitempty"`
+}
+
+// EcdhInfo
+// mask - Encrypted commitment mask, only before RCTTypeBulletproof2.
+// amount - Encrypted amount.
+type EcdhInfo struct {
+	Mask   string `json:"mask,omitempty"`
+	Amount string `json:"amount"`
+}
+
+// RctSigPrunable
+// nbp - Number of range proofs.
+// bp - Bulletproofs, RCTTypeBulletproof to RCTTypeCLSAG.
+// bpp - Bulletproofs+, RCTTypeBulletproofPlus.
+// MGs - MLSAG ring signatures, before RCTTypeCLSAG.
+// CLSAGs - CLSAG ring signatures.
+// pseudoOuts - Pseudo output commitments, one per input.
+type RctSigPrunable struct {
+	Nbp        uint64            `json:"nbp,omitempty"`
+	Bp         []Bulletproof     `json:"bp,omitempty"`
+	Bpp        []BulletproofPlus `json:"bpp,omitempty"`
+	MGs        []MGSignature     `json:"MGs,omitempty"`
+	CLSAGs     []CLSAGSignature  `json:"CLSAGs,omitempty"`
+	PseudoOuts []string          `json:"pseudoOuts,omitempty"`
+}
+
+// Bulletproof is a bulletproof range proof. The lower case scalars a, b and
+// t are decoded into AScalar, BScalar and TScalar.
+type Bulletproof struct {
+	A       string   `json:"A"`
+	S       string   `json:"S"`
+	T1      string   `json:"T1"`
+	T2      string   `json:"T2"`
+	Taux    string   `json:"taux"`
+	Mu      string   `json:"mu"`
+	L       []string `json:"L"`
+	R       []string `json:"R"`
+	AScalar string   `json:"a"`
+	BScalar string   `json:"b"`
+	TScalar string   `json:"t"`
+}
+
+// BulletproofPlus is a bulletproof+ range proof.
+type BulletproofPlus struct {
+	A  string   `json:"A"`
+	A1 string   `json:"A1"`
+	B  string   `json:"B"`
+	R1 string   `json:"r1"`
+	S1 string   `json:"s1"`
+	D1 string   `json:"d1"`
+	L  []string `json:"L"`
+	R  []string `json:"R"`
+}
+
+// MGSignature is an MLSAG ring signature.
+type MGSignature struct {
+	Ss [][]string `json:"ss"`
+	Cc string     `json:"cc"`
+}
+
+// CLSAGSignature is a CLSAG ring signature.
+type CLSAGSignature struct {
+	S  []string `json:"s"`
+	C1 string   `json:"c1"`
+	D  string   `json:"D"`
+}
+
+// TxExtra is the raw extra field of a transaction. In JSON it is an array of
+// byte values rather than a base64 string.
+type TxExtra []byte
+
+// MarshalJSON encodes the extra as an array of numbers.
+func (e TxExtra) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+4*len(e))
+	buf = append(buf, '[')
+	for i, b := range e {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendUint(buf, uint64(b), 10)
+	}
+	return append(buf, ']'), nil
+}
+
+// UnmarshalJSON decodes an array of byte values.
+func (e *TxExtra) UnmarshalJSON(data []byte) error {
+	var numbers []json.Number
+	if err := json.Unmarshal(data, &numbers); err != nil {
+		return err
+	}
+	values := make([]byte, len(numbers))
+	for i, n := range numbers {
+		v, err := strconv.ParseUint(n.String(), 10, 8)
+		if err != nil {
+			return err
+		}
+		values[i] = byte(v)
+	}
+	*e = values
+	return nil
+}
+
+// SendRawTransactionResponse
+// status - string; General RPC error code. "OK" means everything looks good.
+// reason - string; Additional information on why the transaction was rejected.
+// not_relayed - boolean; Transaction was accepted but not relayed.
+// double_spend - boolean; Transaction is a double spend.
+// fee_too_low - boolean; Fee is too low.
+// invalid_input - boolean; Input is invalid.
+// invalid_output - boolean; Output is invalid.
+// low_mixin - boolean; Ring size is too low.
+// nonzero_unlock_time - boolean; Unlock time is set, which is no longer allowed.
+// overspend - boolean; Transaction uses more money than available.
+// too_big - boolean; Transaction size is too big.
+// too_few_outputs - boolean; Transaction has less than two outputs.
+// sanity_check_failed - boolean; Transaction failed the daemon's sanity checks.
+// tx_extra_too_big - boolean; Transaction extra is too big.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type SendRawTransactionResponse struct {
+	Status            string `json:"status"`
+	Reason            string `json:"reason"`
+	NotRelayed        bool   `json:"not_relayed"`
+	DoubleSpend       bool   `json:"double_spend"`
+	FeeTooLow         bool   `json:"fee_too_low"`
+	InvalidInput      bool   `json:"invalid_input"`
+	InvalidOutput     bool   `json:"invalid_output"`
+	LowMixin          bool   `json:"low_mixin"`
+	NonzeroUnlockTime bool   `json:"nonzero_unlock_time"`
+	Overspend         bool   `json:"overspend"`
+	TooBig            bool   `json:"too_big"`
+	TooFewOutputs     bool   `json:"too_few_outputs"`
+	SanityCheckFailed bool   `json:"sanity_check_failed"`
+	TxExtraTooBig     bool   `json:"tx_extra_too_big"`
+	Untrusted         bool   `json:"untrusted"`
+}
+
+// Reasons returned by SendRawTransaction, wrapped in a TxRejectedError. Use
+// errors.Is to check for them.
+var (
+	ErrDoubleSpend       = errors.New("double spend")
+	ErrFeeTooLow         = errors.New("fee too low")
+	ErrInvalidInput      = errors.New("invalid input")
+	ErrInvalidOutput     = errors.New("invalid output")
+	ErrLowMixin          = errors.New("ring size too low")
+	ErrNonzeroUnlockTime = errors.New("nonzero unlock time")
+	ErrNotRelayed        = errors.New("not relayed")
+	ErrOverspend         = errors.New("overspend")
+	ErrTooBig            = errors.New("transaction too big")
+	ErrTooFewOutputs     = errors.New("too few outputs")
+	ErrSanityCheckFailed = errors.New("sanity check failed")
+	ErrTxExtraTooBig     = errors.New("tx extra too big")
+)
+
+// TxRejectedError is returned by SendRawTransaction when the daemon refuses a
+// transaction. Reasons holds one of the Err values above for every flag the
+// daemon set, it is empty if the daemon gave no specific reason.
+type TxRejectedError struct {
+	Status  string
+	Reason  string
+	Reasons []error
+}
+
+func (e *TxRejectedError) Error() string {
+	msg := "transaction rejected"
+	if e.Status != "" && e.Status != "OK" && e.Status != "Failed" {
+		msg += " (" + e.Status + ")"
+	}
+	for i, reason := range e.Reasons {
+		if i == 0 {
+			msg += ": "
+		} else {
+			msg += ", "
+		}
+		msg += reason.Error()
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Is reports whether target is one of the reasons the transaction was
+// rejected for.
+func (e *TxRejectedError) Is(target error) bool {
+	for _, reason := range e.Reasons {
+		if reason == target {
+			return true
+		}
+	}
+	return false
+}
+
+// rejection returns the error for a send_raw_transaction response, or nil if
+// the transaction was accepted. A transaction that was accepted but not
+// relayed is only an error if relaying was requested.
+func (r SendRawTransactionResponse) rejection(doNotRelay bool) error {
+	if r.Status == "OK" && (doNotRelay || !r.NotRelayed) {
+		return nil
+	}
+	flags := []struct {
+		set bool
+		err error
+	}{
+		{r.DoubleSpend, ErrDoubleSpend},
+		{r.FeeTooLow, ErrFeeTooLow},
+		{r.InvalidInput, ErrInvalidInput},
+		{r.InvalidOutput, ErrInvalidOutput},
+		{r.LowMixin, ErrLowMixin},
+		{r.NonzeroUnlockTime, ErrNonzeroUnlockTime},
+		{r.NotRelayed && !doNotRelay, ErrNotRelayed},
+		{r.Overspend, ErrOverspend},
+		{r.TooBig, ErrTooBig},
+		{r.TooFewOutputs, ErrTooFewOutputs},
+		{r.SanityCheckFailed, ErrSanityCheckFailed},
+		{r.TxExtraTooBig, ErrTxExtraTooBig},
+	}
+	e := &TxRejectedError{Status: r.Status, Reason: r.Reason}
+	for _, f := range flags {
+		if f.set {
+			e.Reasons = append(e.Reasons, f.err)
+		}
+	}
+	return e
+}
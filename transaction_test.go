@@ -0,0 +1,182 @@
+package monero
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testTxHashes = []string{
+	"9ff04fc01326ef7b1d7f3d883de4ecb27927d283bd3be7474198b61ec4521597",
+	"7cddeb8fa53b663f997b945daf8f064a7c556e6cd2d067bb0378c665c042786d",
+	"9f5b12146dd4709ba2fdcdfa5d5f8045d226da49c0d97b7648d3203687ce0d61",
+	"e887e7ab4f6ccd9c3c2c705fc1fd7f32a66b5db18c6edf726631efa7033d25b7",
+}
+
+// testTxBlob is a transaction that is in neither the chain nor the pool of
+// the fixtures.
+const testTxBlob = "020001020010e2a9f218e0d801aef71183e10683a20b90c202c6b014d0a50ef8e60eb0fb03aeb505cf9f15a7b90e91ee04c3b003dfd109a8590cbcb1f0d6420dc741c9ec48e0ac7d1aaf3810817a6068e7b5e59030e46b0200024590f0015587d959dcd2f6d955f4664e462bb04196b6952bb7a77d0b15d333860002979b7f52fb51d409bfc1d5e0e0ca32544d36b1d5dfee378c15f8d93636a593b02c01ddf5407e9cf7af9744b59866d0b791be941ea6ee6dc601bd1883132c15c7c843020901bba1efa405e60a4705a0b4c90f31885bc64f9f81d19d65b5299b0bcd8c7e838289b4cbd10560ee5c7cc2ab9896ef0f4b94eacee040037d3d409c8869f2b8c3f8533a5d03abcb7d1b39f0e17985c7ab63bb204389b797e146dc6d5b84cb01339473946955be8a78e6cd080f6c1e26d8a38ec1c1ede00f8c065d30c25c5d9022fbaa8577038841e124e123872e0b07dee81ade90cce6c0e0f016567cefc2587a86d46ce491d93627a6cd210b2a3fee76c754c06ba542f25f77f44f171a6a69ca11ac5164f640981f9d4a970d15fbcf5f38c0093feb829f6518b9bbe0a835786d8d985f354833fdd0f840044c6b7b7391eb61fc16fa35d42a79f7705906904141882e378aedc37632642dcd1fb642648597dbae92ab38ce754f2be3c765c68f07e557d5e100ed3e55f4af20dd40ec48471ef03755735c5cd337c893ec1698486c93d95ee8d599824625f6eb38f8abcec9e7920d7df70a7332744284c92c3189bf39b7c091d43e9a55b846a320163e927a53960ed21db008140a28803f80650251408cf596e4a4aa15dc2f344ae417ec25dba2f6a16912f6013af6e7b047984cafbc06f9e649bb05e65b5068d1310fd4fd5e4a3c67fc981d4cc523b9129a82108531fe163f05bb0f4208ad3efd8bab9d02ed9aea340c69ea270044fb2ce6e5b14258206191bf4f2380b896f74046be636a951403458f7f371f212c16796a58bcef07222e1db24dcd85594005c6efc59fdb62549a71888a66662cde2280e8993180a2fb1a01f116a6f3003f8e0e1dc0cddbc55ca4a3ad902b416f0fa7123ab91c1a8775dc0affe9a7fd5261ec72d03fad8e9b08655a61f44b4e75e4da5f06c9951ee2b371dd5494c82480afe334fd39d2f1d2a4f2596fecddbca2e188f3444a36c72fe51f9f33b2fd6df7dcba21cea82c6cfd39c287e7461d516bd194c83b227b3f10604638add9d48346fd88c5461186d6063220ae05cb82bc9360704b6f2b02f6e70e1511100b3a1f3e2f9f203aefe2ebbec35cb7f201fb5493f41e831ad7b8b292e9fb2f8c151e367ce39c0d062f86bd7367c327141c6216eb6f1d5762ebfa53405782a43c71d35351e6e011e576da83d0ed535b1b2895ef3a04cac5ae77c60cf549ec7a2b736fbbfaf8d3c9b39f448196fe15d605a72d3f463ce4478a3d128c6b2af337a123ef9cb237f1eafa70c5e0e3b967cd3ee31370e731964247a21a9df91cc39690d6113d46d6aa3a5c2c4d84537d43e3bfacd470e9d51967c2bce10590e1e5b6ad219386bb7d2040a91f1a5d08b4b54f00ee830b28105f7a64ce5c227f78074ba7a041485abeefc66862b601f0ecc375b47fb6fcd5e4a1a74cc8ae62602a89f607d96fb3173ce6e65c651d11e3f8882954158d3b6fd0a869394ffd0f2f4b6734fabe4145f4601ba3b7f9e65df1e2845a4a1bd8d33626a23e8f40b8695a4285e731116bd019f3d85d50571493897a1100748cbb7602a0c8225204096ad328dabdb5c8ab82572529c077ecf5169e7170c066184e81ea33a1c8388f6d695e15338cdb10542cf33571c1c8a6e1b805569e056b4818c7a2fdcbab94918449964dfc4d9b3d03e8da9f60e91c75c4c015ba52889308af6102d4310f9e44ce4e3e0989da14534f0cd0fafcd0e0f4a909559612ec5e74b4e6f22465843cbf52667a1efa02119a0c27f6a764c1e41871d87dc8f08eaee75215bfba522681d44e01c8d199e6219fdecbcf7bdb7cdbf813b02bfe685e23dedee62850b94087747f01f934a7a485cd01bc37c6c0269bf5a010dc5d36c96c0966b392b3195011434a074b790e5c97be537297a74fae37e8852fd8805d1fb252ada58b258ec36cdc89d72872d51292d9fc22aa06236434bae19ed9d491e317dd2073c365f034daa9b68ee652be3c661bfc5329e9049cf02abe0b0551210181b44a4f96b9771a44a91e5812535884284015f46dfa7d2b3b83fde571ee91e499e063e40943aaf97ef1908b959d1ee84b9a399fca637fe8cf9a20309cab1a4315fbc02759ebb0324fe197d52c"
+
+func loadTransactions(t *testing.T) TransactionsResponse {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", "daemon", "get_transactions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tr TransactionsResponse
+	if err := json.Unmarshal(data, &tr); err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestTransactionJSON(t *testing.T) {
+	tr := loadTransactions(t)
+	if len(tr.Txs) != 3 {
+		t.Fatalf("got %d transactions, want 3", len(tr.Txs))
+	}
+	for _, e := range tr.Txs {
+		tx, err := e.Transaction()
+		if err != nil {
+			t.Fatalf("%s: %v", e.TxHash, err)
+		}
+		drift := DetectSchemaDrift("as_json", []byte(e.AsJSON), &tx)
+		if drift.Detected() {
+			t.Errorf("%s: unknown %v, missing %v", e.TxHash, drift.Unknown, drift.Missing)
+		}
+
+		// Re-encoding must not lose anything.
+		data, err := json.Marshal(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got, want interface{}
+		json.Unmarshal(data, &got)
+		json.Unmarshal([]byte(e.AsJSON), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: round trip mismatch\n\t%s\nwant\n\t%s", e.TxHash, data, e.AsJSON)
+		}
+
+		if tx.Version != 2 || tx.RctSignatures == nil || tx.RctSignatures.Type != RCTTypeCLSAG {
+			t.Errorf("%s: unexpected version %d or rct signatures %+v", e.TxHash, tx.Version, tx.RctSignatures)
+		}
+		if len(tx.Inputs) == 0 || tx.Inputs[0].Key == nil || len(tx.Inputs[0].Key.KeyOffsets) != 16 {
+			t.Errorf("%s: expected key inputs with a ring of 16", e.TxHash)
+		}
+		for _, out := range tx.Outputs {
+			if out.Target.TaggedKey != nil || out.Target.PublicKey() != out.Target.Key {
+				t.Errorf("%s: expected untagged key outputs, got %+v", e.TxHash, out.Target)
+			}
+		}
+		if e.Pruned() != (tx.RctSigPrunable == nil) {
+			t.Errorf("%s: pruned %v but prunable signatures %+v", e.TxHash, e.Pruned(), tx.RctSigPrunable)
+		}
+		if !e.Pruned() && len(tx.RctSigPrunable.CLSAGs) != len(tx.Inputs) {
+			t.Errorf("%s: %d CLSAGs for %d inputs", e.TxHash, len(tx.RctSigPrunable.CLSAGs), len(tx.Inputs))
+		}
+	}
+}
+
+func TestTransactionEntry(t *testing.T) {
+	tr := loadTransactions(t)
+	confirmed, pool, pruned := tr.Txs[0], tr.Txs[1], tr.Txs[2]
+
+	if confirmed.Pruned() || confirmed.Hex() != confirmed.AsHex || len(confirmed.OutputIndices) != 2 {
+		t.Errorf("unexpected confirmed transaction %+v", confirmed)
+	}
+	if !pool.InPool || pool.BlockHeight != 0 || !pool.Relayed || pool.ReceivedTimestamp == 0 {
+		t.Errorf("unexpected pool transaction %+v", pool)
+	}
+	if !pruned.Pruned() || pruned.Hex() != "" || pruned.PrunableHash == "" {
+		t.Errorf("unexpected pruned transaction %+v", pruned)
+	}
+	if len(tr.MissedTx) != 1 || tr.MissedTx[0] != testTxHashes[3] {
+		t.Errorf("missed %v, want %v", tr.MissedTx, testTxHashes[3:])
+	}
+
+	split := TransactionEntry{PrunedAsHex: "0201", PrunableAsHex: "ff"}
+	if split.Pruned() || split.Hex() != "0201ff" {
+		t.Errorf("split transaction: pruned %v, hex %q", split.Pruned(), split.Hex())
+	}
+	if _, err := (TransactionEntry{}).Transaction(); err != ErrNotDecoded {
+		t.Errorf("got %v, want ErrNotDecoded", err)
+	}
+}
+
+func TestRingMembers(t *testing.T) {
+	in := TxInputToKey{KeyOffsets: []uint64{100, 5, 1, 20}}
+	if got, want := in.RingMembers(), []uint64{100, 105, 106, 126}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSendRawTransactionRejected(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   string
+		doNotRelay bool
+		reasons    []error
+	}{
+		{
+			name:     "double spend",
+			response: `{"status":"Failed","reason":"","double_spend":true,"low_mixin":true}`,
+			reasons:  []error{ErrDoubleSpend, ErrLowMixin},
+		},
+		{
+			name:     "fee",
+			response: `{"status":"Failed","reason":"fee too low","fee_too_low":true}`,
+			reasons:  []error{ErrFeeTooLow},
+		},
+		{
+			name:     "no reason",
+			response: `{"status":"Failed","reason":"Failed to parse tx"}`,
+		},
+		{
+			name:     "not relayed",
+			response: `{"status":"OK","reason":"Not relayed","not_relayed":true}`,
+			reasons:  []error{ErrNotRelayed},
+		},
+		{
+			name:       "do not relay",
+			response:   `{"status":"OK","reason":"Not relayed","not_relayed":true}`,
+			doNotRelay: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, tc.response)
+			}))
+			defer srv.Close()
+			c := NewDaemonClient(srv.URL + "/json_rpc")
+
+			_, err := c.SendRawTransaction("00", tc.doNotRelay)
+			if tc.doNotRelay {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var rejected *TxRejectedError
+			if !errors.As(err, &rejected) {
+				t.Fatalf("got %v, want a TxRejectedError", err)
+			}
+			if !reflect.DeepEqual(rejected.Reasons, tc.reasons) {
+				t.Errorf("got reasons %v, want %v", rejected.Reasons, tc.reasons)
+			}
+			for _, reason := range tc.reasons {
+				if !errors.Is(err, reason) {
+					t.Errorf("errors.Is(%v, %v) is false", err, reason)
+				}
+			}
+			if errors.Is(err, ErrOverspend) {
+				t.Errorf("errors.Is(%v, ErrOverspend) is true", err)
+			}
+		})
+	}
+}
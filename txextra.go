@@ -0,0 +1,359 @@
+package monero
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Tags of the fields of a transaction's extra.
+const (
+	TxExtraTagPadding           = 0x00
+	TxExtraTagPubKey            = 0x01
+	TxExtraTagNonce             = 0x02
+	TxExtraTagMergeMining       = 0x03
+	TxExtraTagAdditionalPubKeys = 0x04
+	TxExtraTagMinergate         = 0xde
+)
+
+// Size limits of extra fields.
+const (
+	// TxExtraPaddingMaxCount is the largest padding, tag included.
+	TxExtraPaddingMaxCount = 255
+	// TxExtraNonceMaxCount is the largest extra nonce.
+	TxExtraNonceMaxCount = 255
+)
+
+// Prefixes of the payment ids stored in an extra nonce.
+const (
+	txExtraNoncePaymentID          = 0x00
+	txExtraNonceEncryptedPaymentID = 0x01
+	paymentIDSize                  = 32
+	encryptedPaymentIDSize         = 8
+)
+
+// ErrMalformedTxExtra matches the errors of extras the daemon would fail to
+// parse. Use errors.Is to check for it.
+var ErrMalformedTxExtra = errors.New("malformed tx extra")
+
+// TxExtraError is returned by TxExtra.Parse for an extra with a field it
+// cannot parse. The fields before it are still returned, like the daemon and
+// wallet do, and the unparsed rest of the extra starts at Offset.
+type TxExtraError struct {
+	Offset int
+	Tag    byte
+	Err    error
+}
+
+func (e *TxExtraError) Error() string {
+	return fmt.Sprintf("tx extra field %#x at offset %d: %v", e.Tag, e.Offset, e.Err)
+}
+
+// Is makes errors.Is(err, ErrMalformedTxExtra) true.
+func (e *TxExtraError) Is(target error) bool {
+	return target == ErrMalformedTxExtra
+}
+
+func (e *TxExtraError) Unwrap() error {
+	return e.Err
+}
+
+// TxExtraField is a field of a transaction's extra. Which of the other
+// members is set depends on Tag.
+type TxExtraField struct {
+	Tag byte
+	// PubKey is the transaction public key of a TxExtraTagPubKey field.
+	PubKey string
+	// PubKeys are the keys of a TxExtraTagAdditionalPubKeys field, one per
+	// output of transactions to subaddresses.
+	PubKeys []string
+	// Nonce is the data of a TxExtraTagNonce field, e.g. a payment id.
+	Nonce []byte
+	// MergeMining is the tag of a TxExtraTagMergeMining field.
+	MergeMining MergeMiningTag
+	// Padding is the size of a TxExtraTagPadding field, tag included.
+	Padding int
+	// Data is the data of a TxExtraTagMinergate field. For unknown tags it
+	// holds the rest of the extra, since their size is unknown.
+	Data []byte
+}
+
+// MergeMiningTag commits a miner transaction to the blocks of merge mined
+// chains.
+type MergeMiningTag struct {
+	Depth      uint64
+	MerkleRoot string
+}
+
+// Params decodes the merkle tree parameters of the tag.
+func (m MergeMiningTag) Params() MerkleTreeParams {
+	return DecodeMerkleTreeDepth(m.Depth)
+}
+
+// TxExtraFields are the parsed fields of a transaction's extra, in order.
+type TxExtraFields []TxExtraField
+
+// Parse parses the fields of the extra. On a field it cannot parse it
+// returns the fields before it with a *TxExtraError; a field with an unknown
+// tag is returned too, with the rest of the extra as its Data.
+func (e TxExtra) Parse() (TxExtraFields, error) {
+	var fields TxExtraFields
+	r := &blobReader{b: e}
+	for r.pos < len(e) {
+		start := r.pos
+		tag := e[start]
+		r.pos++
+		f, err := parseTxExtraField(r, tag)
+		if err != nil {
+			if errors.Is(err, errUnknownTxExtraTag) {
+				fields = append(fields, TxExtraField{Tag: tag, Data: append([]byte(nil), e[start+1:]...)})
+			}
+			return fields, &TxExtraError{Offset: start, Tag: tag, Err: err}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+var errUnknownTxExtraTag = errors.New("unknown tag")
+
+func parseTxExtraField(r *blobReader, tag byte) (TxExtraField, error) {
+	f := TxExtraField{Tag: tag}
+	var err error
+	switch tag {
+	case TxExtraTagPadding:
+		// Padding runs to the end of the extra and must be all zeros.
+		f.Padding = 1 + len(r.b) - r.pos
+		if f.Padding > TxExtraPaddingMaxCount {
+			return f, fmt.Errorf("padding of %d bytes exceeds %d", f.Padding, TxExtraPaddingMaxCount)
+		}
+		for ; r.pos < len(r.b); r.pos++ {
+			if r.b[r.pos] != 0 {
+				return f, fmt.Errorf("non-zero padding byte at offset %d", r.pos)
+			}
+		}
+	case TxExtraTagPubKey:
+		f.PubKey, err = r.hex(keySize)
+	case TxExtraTagNonce:
+		f.Nonce, err = txExtraString(r)
+		if err == nil && len(f.Nonce) > TxExtraNonceMaxCount {
+			err = fmt.Errorf("nonce of %d bytes exceeds %d", len(f.Nonce), TxExtraNonceMaxCount)
+		}
+	case TxExtraTagMergeMining:
+		var data []byte
+		if data, err = txExtraString(r); err != nil {
+			break
+		}
+		mr := &blobReader{b: data}
+		if f.MergeMining.Depth, err = mr.varint(); err != nil {
+			break
+		}
+		if f.MergeMining.MerkleRoot, err = mr.hex(keySize); err != nil {
+			break
+		}
+		if mr.pos != len(data) {
+			err = fmt.Errorf("%d trailing bytes in the merge mining tag", len(data)-mr.pos)
+		}
+	case TxExtraTagAdditionalPubKeys:
+		f.PubKeys, err = r.keyList()
+	case TxExtraTagMinergate:
+		f.Data, err = txExtraString(r)
+	default:
+		err = errUnknownTxExtraTag
+	}
+	return f, err
+}
+
+// txExtraString reads a varint length followed by that many bytes.
+func txExtraString(r *blobReader) ([]byte, error) {
+	n, err := r.count(1)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.bytes(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), b...), nil
+}
+
+// find returns the first field with tag, the one the daemon and wallet use.
+func (fs TxExtraFields) find(tag byte) (TxExtraField, bool) {
+	for _, f := range fs {
+		if f.Tag == tag {
+			return f, true
+		}
+	}
+	return TxExtraField{}, false
+}
+
+// PubKey returns the transaction public key.
+func (fs TxExtraFields) PubKey() (string, bool) {
+	f, ok := fs.find(TxExtraTagPubKey)
+	return f.PubKey, ok
+}
+
+// AdditionalPubKeys returns the additional public keys of a transaction to
+// subaddresses, one per output.
+func (fs TxExtraFields) AdditionalPubKeys() []string {
+	f, _ := fs.find(TxExtraTagAdditionalPubKeys)
+	return f.PubKeys
+}
+
+// ExtraNonce returns the data of the extra nonce.
+func (fs TxExtraFields) ExtraNonce() ([]byte, bool) {
+	f, ok := fs.find(TxExtraTagNonce)
+	return f.Nonce, ok
+}
+
+// PaymentID returns the unencrypted 32-byte payment id of the extra nonce as
+// hex.
+func (fs TxExtraFields) PaymentID() (string, bool) {
+	nonce, ok := fs.ExtraNonce()
+	if !ok || len(nonce) != 1+paymentIDSize || nonce[0] != txExtraNoncePaymentID {
+		return "", false
+	}
+	return hex.EncodeToString(nonce[1:]), true
+}
+
+// EncryptedPaymentID returns the encrypted 8-byte payment id of the extra
+// nonce as hex.
+func (fs TxExtraFields) EncryptedPaymentID() (string, bool) {
+	nonce, ok := fs.ExtraNonce()
+	if !ok || len(nonce) != 1+encryptedPaymentIDSize || nonce[0] != txExtraNonceEncryptedPaymentID {
+		return "", false
+	}
+	return hex.EncodeToString(nonce[1:]), true
+}
+
+// MergeMiningTag returns the merge mining tag of a miner transaction.
+func (fs TxExtraFields) MergeMiningTag() (MergeMiningTag, bool) {
+	f, ok := fs.find(TxExtraTagMergeMining)
+	return f.MergeMining, ok
+}
+
+// Encode serializes the fields into an extra. Padding and fields with
+// unknown tags run to the end of the extra, so they must come last.
+func (fs TxExtraFields) Encode() (TxExtra, error) {
+	w := &blobWriter{b: []byte{}}
+	for i, f := range fs {
+		if err := encodeTxExtraField(w, f, i == len(fs)-1); err != nil {
+			return nil, fmt.Errorf("tx extra field %d: %w", i, err)
+		}
+	}
+	return TxExtra(w.b), nil
+}
+
+func encodeTxExtraField(w *blobWriter, f TxExtraField, last bool) error {
+	w.b = append(w.b, f.Tag)
+	switch f.Tag {
+	case TxExtraTagPadding:
+		if f.Padding < 1 || f.Padding > TxExtraPaddingMaxCount {
+			return fmt.Errorf("invalid padding size %d", f.Padding)
+		}
+		if !last {
+			return errors.New("padding must be the last field")
+		}
+		w.b = append(w.b, make([]byte, f.Padding-1)...)
+	case TxExtraTagPubKey:
+		return w.hex(f.PubKey, keySize)
+	case TxExtraTagNonce:
+		if len(f.Nonce) > TxExtraNonceMaxCount {
+			return fmt.Errorf("nonce of %d bytes exceeds %d", len(f.Nonce), TxExtraNonceMaxCount)
+		}
+		w.varint(uint64(len(f.Nonce)))
+		w.b = append(w.b, f.Nonce...)
+	case TxExtraTagMergeMining:
+		mw := &blobWriter{}
+		mw.varint(f.MergeMining.Depth)
+		if err := mw.hex(f.MergeMining.MerkleRoot, keySize); err != nil {
+			return err
+		}
+		w.varint(uint64(len(mw.b)))
+		w.b = append(w.b, mw.b...)
+	case TxExtraTagAdditionalPubKeys:
+		return w.keyList(f.PubKeys)
+	case TxExtraTagMinergate:
+		w.varint(uint64(len(f.Data)))
+		w.b = append(w.b, f.Data...)
+	default:
+		if !last {
+			return fmt.Errorf("field with unknown tag %#x must be the last field", f.Tag)
+		}
+		w.b = append(w.b, f.Data...)
+	}
+	return nil
+}
+
+// TxExtraBuilder builds an extra field by field, in the order of the calls.
+// The first error is returned by Build.
+type TxExtraBuilder struct {
+	fields TxExtraFields
+	err    error
+}
+
+// Field adds f.
+func (b *TxExtraBuilder) Field(f TxExtraField) *TxExtraBuilder {
+	b.fields = append(b.fields, f)
+	return b
+}
+
+// PubKey adds the transaction public key, given as hex.
+func (b *TxExtraBuilder) PubKey(key string) *TxExtraBuilder {
+	return b.Field(TxExtraField{Tag: TxExtraTagPubKey, PubKey: key})
+}
+
+// AdditionalPubKeys adds the additional public keys of a transaction to
+// subaddresses, given as hex.
+func (b *TxExtraBuilder) AdditionalPubKeys(keys []string) *TxExtraBuilder {
+	return b.Field(TxExtraField{Tag: TxExtraTagAdditionalPubKeys, PubKeys: keys})
+}
+
+// Nonce adds an extra nonce.
+func (b *TxExtraBuilder) Nonce(nonce []byte) *TxExtraBuilder {
+	return b.Field(TxExtraField{Tag: TxExtraTagNonce, Nonce: nonce})
+}
+
+// PaymentID adds an extra nonce with an unencrypted 32-byte payment id,
+// given as hex.
+func (b *TxExtraBuilder) PaymentID(id string) *TxExtraBuilder {
+	return b.paymentID(txExtraNoncePaymentID, id, paymentIDSize)
+}
+
+// EncryptedPaymentID adds an extra nonce with an encrypted 8-byte payment
+// id, given as hex.
+func (b *TxExtraBuilder) EncryptedPaymentID(id string) *TxExtraBuilder {
+	return b.paymentID(txExtraNonceEncryptedPaymentID, id, encryptedPaymentIDSize)
+}
+
+func (b *TxExtraBuilder) paymentID(prefix byte, id string, size int) *TxExtraBuilder {
+	raw, err := hex.DecodeString(id)
+	if err == nil && len(raw) != size {
+		err = fmt.Errorf("payment id of %d bytes, want %d", len(raw), size)
+	}
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.Nonce(append([]byte{prefix}, raw...))
+}
+
+// MergeMiningTag adds a merge mining tag.
+func (b *TxExtraBuilder) MergeMiningTag(tag MergeMiningTag) *TxExtraBuilder {
+	return b.Field(TxExtraField{Tag: TxExtraTagMergeMining, MergeMining: tag})
+}
+
+// Padding adds size bytes of padding, tag included. It must be added last.
+func (b *TxExtraBuilder) Padding(size int) *TxExtraBuilder {
+	return b.Field(TxExtraField{Tag: TxExtraTagPadding, Padding: size})
+}
+
+// Build encodes the added fields.
+func (b *TxExtraBuilder) Build() (TxExtra, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.fields.Encode()
+}
@@ -0,0 +1,203 @@
+package monero
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTxExtraCaptured(t *testing.T) {
+	for _, e := range blobEntries(t) {
+		tx, err := e.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields, err := tx.Extra.Parse()
+		if err != nil {
+			t.Errorf("%s: %v", e.TxHash, err)
+			continue
+		}
+		if _, ok := fields.PubKey(); !ok {
+			t.Errorf("%s: no transaction public key", e.TxHash)
+		}
+		extra, err := fields.Encode()
+		if err != nil || !bytes.Equal(extra, tx.Extra) {
+			t.Errorf("%s: encoded as %v, %v, want %v", e.TxHash, extra, err, tx.Extra)
+		}
+	}
+}
+
+func TestTxExtraMalformedMinerTx(t *testing.T) {
+	b := Block{Json: loadBlockJSON(t)}
+	details, err := b.ParseJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := details.MinerTransactionInfo.Extra
+	fields, err := extra.Parse()
+	var extraErr *TxExtraError
+	if !errors.As(err, &extraErr) || !errors.Is(err, ErrMalformedTxExtra) {
+		t.Fatalf("got %v, want a TxExtraError", err)
+	}
+	// The padding after the two byte nonce is not all zeros.
+	if extraErr.Offset != 37 || extraErr.Tag != TxExtraTagPadding {
+		t.Errorf("got error at offset %d with tag %#x", extraErr.Offset, extraErr.Tag)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("got %d fields, want 2", len(fields))
+	}
+	if key, ok := fields.PubKey(); !ok || key != "8c8d3a343e727bb84d3a7d801d7e2f0e48509ad64dd8f03395424f9c3ae4f0e2" {
+		t.Errorf("got public key %q", key)
+	}
+	if nonce, ok := fields.ExtraNonce(); !ok || !bytes.Equal(nonce, []byte{17, 0}) {
+		t.Errorf("got nonce %v", nonce)
+	}
+}
+
+func loadBlockJSON(t *testing.T) string {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "fixtures", "daemon", "getblock.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep struct {
+		Result Block `json:"result"`
+	}
+	if err := json.Unmarshal(data, &rep); err != nil {
+		t.Fatal(err)
+	}
+	return rep.Result.Json
+}
+
+func TestTxExtraBuilder(t *testing.T) {
+	key := strings.Repeat("11", 32)
+	keys := []string{strings.Repeat("22", 32), strings.Repeat("33", 32)}
+	id := strings.Repeat("ab", 32)
+	tag := MergeMiningTag{Depth: 24, MerkleRoot: strings.Repeat("44", 32)}
+
+	extra, err := (&TxExtraBuilder{}).
+		PubKey(key).
+		AdditionalPubKeys(keys).
+		PaymentID(id).
+		MergeMiningTag(tag).
+		Field(TxExtraField{Tag: TxExtraTagMinergate, Data: []byte{1, 2, 3}}).
+		Padding(4).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extra) != 33+(2+64)+(2+33)+(2+33)+(2+3)+4 {
+		t.Errorf("got %d bytes", len(extra))
+	}
+	fields, err := extra.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := fields.PubKey(); !ok || got != key {
+		t.Errorf("got public key %q", got)
+	}
+	if got := fields.AdditionalPubKeys(); !reflect.DeepEqual(got, keys) {
+		t.Errorf("got additional keys %v", got)
+	}
+	if got, ok := fields.PaymentID(); !ok || got != id {
+		t.Errorf("got payment id %q", got)
+	}
+	if _, ok := fields.EncryptedPaymentID(); ok {
+		t.Error("got an encrypted payment id")
+	}
+	if got, ok := fields.MergeMiningTag(); !ok || got != tag {
+		t.Errorf("got merge mining tag %+v", got)
+	}
+	if p := tag.Params(); p.NumChains != 2 || p.Nonce != 1 {
+		t.Errorf("got params %+v", p)
+	}
+	if f := fields[len(fields)-1]; f.Tag != TxExtraTagPadding || f.Padding != 4 {
+		t.Errorf("got last field %+v", f)
+	}
+	again, err := fields.Encode()
+	if err != nil || !bytes.Equal(again, extra) {
+		t.Errorf("re-encoded as %v, %v", again, err)
+	}
+
+	extra, err = (&TxExtraBuilder{}).EncryptedPaymentID("0102030405060708").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, _ = extra.Parse()
+	if got, ok := fields.EncryptedPaymentID(); !ok || got != "0102030405060708" {
+		t.Errorf("got encrypted payment id %q", got)
+	}
+	if _, ok := fields.PaymentID(); ok {
+		t.Error("got an unencrypted payment id")
+	}
+
+	for name, b := range map[string]*TxExtraBuilder{
+		"short key":        (&TxExtraBuilder{}).PubKey("1234"),
+		"bad payment id":   (&TxExtraBuilder{}).PaymentID("0102"),
+		"long nonce":       (&TxExtraBuilder{}).Nonce(make([]byte, 256)),
+		"padding not last": (&TxExtraBuilder{}).Padding(2).PubKey(key),
+		"long padding":     (&TxExtraBuilder{}).Padding(256),
+		"unknown not last": (&TxExtraBuilder{}).Field(TxExtraField{Tag: 0x7f}).PubKey(key),
+	} {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
+
+func TestTxExtraParse(t *testing.T) {
+	key := bytes.Repeat([]byte{0x11}, 32)
+	pubKey := append([]byte{TxExtraTagPubKey}, key...)
+	withKey := func(b ...byte) []byte {
+		return append(append([]byte{}, pubKey...), b...)
+	}
+	cases := []struct {
+		name   string
+		extra  []byte
+		fields int
+		offset int // of the error, -1 for none
+	}{
+		{"empty", nil, 0, -1},
+		{"public key", pubKey, 1, -1},
+		{"short public key", pubKey[:20], 0, 0},
+		{"padding", withKey(0, 0, 0), 2, -1},
+		{"max padding", withKey(make([]byte, TxExtraPaddingMaxCount)...), 2, -1},
+		{"long padding", withKey(make([]byte, TxExtraPaddingMaxCount+1)...), 1, 33},
+		{"dirty padding", withKey(0, 0, 1), 1, 33},
+		{"nonce", withKey(TxExtraTagNonce, 2, 7, 7), 2, -1},
+		{"short nonce", withKey(TxExtraTagNonce, 3, 7, 7), 1, 33},
+		{"long nonce", append([]byte{TxExtraTagNonce, 0x80, 0x02}, make([]byte, 256)...), 0, 0},
+		{"short additional keys", append([]byte{TxExtraTagAdditionalPubKeys, 2}, key...), 0, 0},
+		{"merge mining trailing", append(append([]byte{TxExtraTagMergeMining, 35, 8}, key...), 0xff, TxExtraTagPadding), 0, 0},
+		{"unknown tag", withKey(0x7f, 1, 2), 2, 33},
+	}
+	for _, c := range cases {
+		fields, err := TxExtra(c.extra).Parse()
+		if len(fields) != c.fields {
+			t.Errorf("%s: got %d fields, want %d", c.name, len(fields), c.fields)
+		}
+		var extraErr *TxExtraError
+		switch {
+		case c.offset < 0 && err != nil:
+			t.Errorf("%s: %v", c.name, err)
+		case c.offset >= 0 && !errors.As(err, &extraErr):
+			t.Errorf("%s: got %v, want a TxExtraError", c.name, err)
+		case c.offset >= 0 && extraErr.Offset != c.offset:
+			t.Errorf("%s: got error at offset %d, want %d", c.name, extraErr.Offset, c.offset)
+		}
+	}
+
+	// Fields with unknown tags keep the rest of the extra and encode back.
+	extra := TxExtra(withKey(0x7f, 1, 2))
+	fields, _ := extra.Parse()
+	if f := fields[1]; f.Tag != 0x7f || !bytes.Equal(f.Data, []byte{1, 2}) {
+		t.Errorf("got unknown field %+v", f)
+	}
+	if again, err := fields.Encode(); err != nil || !bytes.Equal(again, extra) {
+		t.Errorf("re-encoded as %v, %v", again, err)
+	}
+}
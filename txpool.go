@@ -0,0 +1,280 @@
+package monero
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// TransactionPoolResponse
+// transactions - List of transactions in the pool, see TxPoolEntry.
+// spent_key_images - List of key images spent by pool transactions, see SpentKeyImage.
+// status - string; General RPC error code. "OK" means everything looks good.
+// untrusted - boolean; True if the response comes from a bootstrap daemon.
+type TransactionPoolResponse struct {
+	Transactions   []TxPoolEntry   `json:"transactions,omitempty"`
+	SpentKeyImages []SpentKeyImage `json:"spent_key_images,omitempty"`
+	Status         string          `json:"status"`
+	Untrusted      bool            `json:"untrusted"`
+}
+
+// TxPoolEntry
+// id_hash - string; The transaction hash.
+// tx_blob - string; The full transaction as hex.
+// tx_json - json string; The transaction decoded as JSON, see Transaction.
+// blob_size - unsigned int; The size of the transaction in bytes.
+// weight - unsigned int; The weight of the transaction.
+// fee - unsigned int; The fee of the transaction in atomic units.
+// receive_time - unsigned int; The Unix time the transaction was received by the node.
+// relayed - boolean; States if the transaction has been relayed to other nodes.
+// last_relayed_time - unsigned int; Last Unix time the transaction was relayed.
+// do_not_relay - boolean; States if the transaction must not be relayed.
+// double_spend_seen - boolean; States if the transaction is a double spend.
+// kept_by_block - boolean; States if the transaction was returned to the pool from a popped block.
+// last_failed_height - unsigned int; Height of the last block the transaction failed to be mined in, 0 if it never failed.
+// last_failed_id_hash - string; Hash of the last block the transaction failed to be mined in.
+// max_used_block_height - unsigned int; Height of the newest block the transaction's inputs reference.
+// max_used_block_id_hash - string; Hash of the newest block the transaction's inputs reference.
+type TxPoolEntry struct {
+	IDHash             string `json:"id_hash"`
+	TxBlob             string `json:"tx_blob"`
+	TxJSON             string `json:"tx_json"`
+	BlobSize           uint64 `json:"blob_size"`
+	Weight             uint64 `json:"weight"`
+	Fee                uint64 `json:"fee"`
+	ReceiveTime        uint64 `json:"receive_time"`
+	Relayed            bool   `json:"relayed"`
+	LastRelayedTime    uint64 `json:"last_relayed_time"`
+	DoNotRelay         bool   `json:"do_not_relay"`
+	DoubleSpendSeen    bool   `json:"double_spend_seen"`
+	KeptByBlock        bool   `json:"kept_by_block"`
+	LastFailedHeight   uint64 `json:"last_failed_height"`
+	LastFailedIDHash   string `json:"last_failed_id_hash"`
+	MaxUsedBlockHeight uint64 `json:"max_used_block_height"`
+	MaxUsedBlockIDHash string `json:"max_used_block_id_hash"`
+}
+
+// Failing reports whether the transaction failed to be included in a block,
+// e.g. because its inputs were spent in the meantime.
+func (e TxPoolEntry) Failing() bool {
+	return e.LastFailedHeight != 0
+}
+
+// Transaction parses the JSON form of the transaction.
+func (e TxPoolEntry) Transaction() (Transaction, error) {
+	var tx Transaction
+	if err := json.Unmarshal([]byte(e.TxJSON), &tx); err != nil {
+		return tx, err
+	}
+	return tx, nil
+}
+
+// SpentKeyImage
+// id_hash - string; The key image.
+// txs_hashes - List of the pool transactions spending the key image. More than one means a double spend.
+type SpentKeyImage struct {
+	IDHash    string   `json:"id_hash"`
+	TxsHashes []string `json:"txs_hashes"`
+}
+
+// TxPoolStats
+// bytes_total - unsigned int; Total size of all transactions in the pool.
+// bytes_min - unsigned int; Size of the smallest transaction.
+// bytes_max - unsigned int; Size of the largest transaction.
+// bytes_med - unsigned int; Median transaction size.
+// fee_total - unsigned int; Sum of the fees of all transactions.
+// oldest - unsigned int; Unix time of the oldest transaction.
+// txs_total - unsigned int; Number of transactions in the pool.
+// num_failing - unsigned int; Number of transactions that failed to be mined.
+// num_10m - unsigned int; Number of transactions older than 10 minutes.
+// num_not_relayed - unsigned int; Number of transactions that were not relayed.
+// num_double_spends - unsigned int; Number of double spends.
+// histo_98pc - unsigned int; The time 98% of the transactions are younger than.
+// histo - List of transaction counts and sizes by age, see TxPoolHisto.
+type TxPoolStats struct {
+	BytesTotal      uint64        `json:"bytes_total"`
+	BytesMin        uint64        `json:"bytes_min"`
+	BytesMax        uint64        `json:"bytes_max"`
+	BytesMed        uint64        `json:"bytes_med"`
+	FeeTotal        uint64        `json:"fee_total"`
+	Oldest          uint64        `json:"oldest"`
+	TxsTotal        uint64        `json:"txs_total"`
+	NumFailing      uint64        `json:"num_failing"`
+	Num10m          uint64        `json:"num_10m"`
+	NumNotRelayed   uint64        `json:"num_not_relayed"`
+	NumDoubleSpends uint64        `json:"num_double_spends"`
+	Histo98pc       uint64        `json:"histo_98pc"`
+	Histo           []TxPoolHisto `json:"histo,omitempty"`
+}
+
+// TxPoolHisto
+// txs - unsigned int; Number of transactions in the bucket.
+// bytes - unsigned int; Total size of the transactions in the bucket.
+type TxPoolHisto struct {
+	Txs   uint64 `json:"txs"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// TxBacklogEntry
+// weight - unsigned int; The weight of the transaction.
+// fee - unsigned int; The fee of the transaction in atomic units.
+// time_in_pool - unsigned int; Seconds the transaction has been in the pool.
+type TxBacklogEntry struct {
+	Weight     uint64 `json:"weight"`
+	Fee        uint64 `json:"fee"`
+	TimeInPool uint64 `json:"time_in_pool"`
+}
+
+// TxBacklog is the pool backlog returned by get_txpool_backlog. The daemon
+// sends it as a binary blob of little endian uint64 triples inside a JSON
+// string.
+type TxBacklog []TxBacklogEntry
+
+// UnmarshalJSON decodes the binary backlog blob.
+func (b *TxBacklog) UnmarshalJSON(data []byte) error {
+	blob, err := decodeBlobString(data)
+	if err != nil {
+		return err
+	}
+	const entrySize = 24
+	if len(blob)%entrySize != 0 {
+		return fmt.Errorf("backlog size %d is not a multiple of %d", len(blob), entrySize)
+	}
+	backlog := make(TxBacklog, len(blob)/entrySize)
+	for i := range backlog {
+		entry := blob[i*entrySize:]
+		backlog[i] = TxBacklogEntry{
+			Weight:     binary.LittleEndian.Uint64(entry),
+			Fee:        binary.LittleEndian.Uint64(entry[8:]),
+			TimeInPool: binary.LittleEndian.Uint64(entry[16:]),
+		}
+	}
+	*b = backlog
+	return nil
+}
+
+// decodeBlobString decodes a JSON string holding binary data the way the
+// daemon encodes it: bytes below 0x20 and the usual specials are escaped and
+// all other bytes are sent raw, which is not always valid UTF-8 and can not
+// be decoded with encoding/json.
+func decodeBlobString(data []byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, errors.New("blob is not a string")
+	}
+	data = data[1 : len(data)-1]
+	blob := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '\\' {
+			blob = append(blob, c)
+			continue
+		}
+		i++
+		if i == len(data) {
+			return nil, errors.New("blob ends in an escape")
+		}
+		switch data[i] {
+		case '"', '\\', '/':
+			blob = append(blob, data[i])
+		case 'b':
+			blob = append(blob, '\b')
+		case 'f':
+			blob = append(blob, '\f')
+		case 'n':
+			blob = append(blob, '\n')
+		case 'r':
+			blob = append(blob, '\r')
+		case 't':
+			blob = append(blob, '\t')
+		case 'u':
+			if i+4 >= len(data) {
+				return nil, errors.New("blob ends in an escape")
+			}
+			v, err := strconv.ParseUint(string(data[i+1:i+5]), 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid escape in blob: %v", err)
+			}
+			blob = append(blob, byte(v))
+			i += 4
+		default:
+			return nil, fmt.Errorf("invalid escape \\%c in blob", data[i])
+		}
+	}
+	return blob, nil
+}
+
+// GetTransactionPool returns the transactions in the daemon's pool and the key
+// images they spend.
+func (c *DaemonClient) GetTransactionPool() (TransactionPoolResponse, error) {
+	var tp TransactionPoolResponse
+	if err := c.DaemonOther("get_transaction_pool", nil, &tp); err != nil {
+		return tp, err
+	}
+	if err := statusError("get_transaction_pool", tp.Status); err != nil {
+		return tp, err
+	}
+	return tp, nil
+}
+
+// GetTransactionPoolHashes returns the hashes of the transactions in the pool.
+func (c *DaemonClient) GetTransactionPoolHashes() ([]string, error) {
+	var response struct {
+		TxHashes []string `json:"tx_hashes,omitempty"`
+		Status   string   `json:"status"`
+	}
+	if err := c.DaemonOther("get_transaction_pool_hashes", nil, &response); err != nil {
+		return nil, err
+	}
+	if err := statusError("get_transaction_pool_hashes", response.Status); err != nil {
+		return nil, err
+	}
+	return response.TxHashes, nil
+}
+
+// GetTransactionPoolStats returns statistics about the transaction pool.
+func (c *DaemonClient) GetTransactionPoolStats() (TxPoolStats, error) {
+	var response struct {
+		PoolStats TxPoolStats `json:"pool_stats"`
+		Status    string      `json:"status"`
+	}
+	if err := c.DaemonOther("get_transaction_pool_stats", nil, &response); err != nil {
+		return response.PoolStats, err
+	}
+	if err := statusError("get_transaction_pool_stats", response.Status); err != nil {
+		return response.PoolStats, err
+	}
+	return response.PoolStats, nil
+}
+
+// GetTxPoolBacklog returns weight, fee and age of every transaction in the
+// pool.
+func (c *DaemonClient) GetTxPoolBacklog() (TxBacklog, error) {
+	var response struct {
+		Backlog TxBacklog `json:"backlog"`
+		Status  string    `json:"status"`
+	}
+	if err := c.Daemon("get_txpool_backlog", nil, &response); err != nil {
+		return response.Backlog, err
+	}
+	if err := statusError("get_txpool_backlog", response.Status); err != nil {
+		return nil, err
+	}
+	return response.Backlog, nil
+}
+
+// FlushTxPool removes the given transactions from the pool, or all
+// transactions if none are given.
+func (c *DaemonClient) FlushTxPool(txIDs ...string) error {
+	var response struct {
+		Status string `json:"status"`
+	}
+	request := struct {
+		TxIDs []string `json:"txids,omitempty"`
+	}{txIDs}
+	if err := c.Daemon("flush_txpool", &request, &response); err != nil {
+		return err
+	}
+	return statusError("flush_txpool", response.Status)
+}
@@ -0,0 +1,93 @@
+package monero
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTxPool(t *testing.T) {
+	srv := newFixtureServer(t, "daemon", false)
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	pool, err := c.GetTransactionPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pool.Transactions) != 2 || len(pool.SpentKeyImages) != 3 {
+		t.Fatalf("got %d transactions and %d key images", len(pool.Transactions), len(pool.SpentKeyImages))
+	}
+	relayed, stuck := pool.Transactions[0], pool.Transactions[1]
+	if relayed.Failing() || !relayed.Relayed {
+		t.Errorf("unexpected relayed transaction %+v", relayed)
+	}
+	if !stuck.Failing() || stuck.Relayed {
+		t.Errorf("unexpected stuck transaction %+v", stuck)
+	}
+	for _, e := range pool.Transactions {
+		tx, err := e.Transaction()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.RctSignatures == nil || tx.RctSignatures.TxnFee != e.Fee {
+			t.Errorf("%s: fee %d does not match transaction %+v", e.IDHash, e.Fee, tx.RctSignatures)
+		}
+	}
+
+	backlog, err := c.GetTxPoolBacklog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TxBacklog{
+		{Weight: 2323, Fee: 46460000, TimeInPool: 112},
+		{Weight: 1632, Fee: 32640000, TimeInPool: 17468},
+	}
+	if !reflect.DeepEqual(backlog, want) {
+		t.Errorf("got backlog %+v, want %+v", backlog, want)
+	}
+}
+
+func TestTxPoolBacklogStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"0","jsonrpc":"2.0","result":{"backlog":"","status":"BUSY"}}`)
+	}))
+	defer srv.Close()
+	c := NewDaemonClient(srv.URL + "/json_rpc")
+
+	backlog, err := c.GetTxPoolBacklog()
+	if rpcErr, ok := err.(*Error); !ok || rpcErr.Code != E_SERVER {
+		t.Fatalf("got %v, want a server error", err)
+	}
+	if backlog != nil {
+		t.Errorf("got backlog %+v with an error", backlog)
+	}
+}
+
+func TestDecodeBlobString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{`""`, []byte{}},
+		{`"\u0000\u001f\b\f\n\r\t\"\\\/"`, []byte{0, 0x1f, '\b', '\f', '\n', '\r', '\t', '"', '\\', '/'}},
+		{"\"\xff\xfeab\"", []byte{0xff, 0xfe, 'a', 'b'}},
+	}
+	for _, tc := range tests {
+		got, err := decodeBlobString([]byte(tc.in))
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got %x, want %x", tc.in, got, tc.want)
+		}
+	}
+	for _, in := range []string{``, `"`, `abc`, `"\"`, `"\u00"`, `"\u0100"`, `"\x"`} {
+		if _, err := decodeBlobString([]byte(in)); err == nil {
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
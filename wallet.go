@@ -240,7 +240,7 @@ func (c *WalletClient) SubmitTransfer(req SubmitTransfer) ([]string, error) {
 // SweepDust will sweep dust inputs from the wallet
 func (c *WalletClient) SweepDust(req SweepDust) (TransferSplit, error) {
 	var response TransferSplit
-	if err := c.Wallet("sweep_dust", nil, &response); err != nil {
+	if err := c.Wallet("sweep_dust", req, &response); err != nil {
 		return response, err
 	}
 	return response, nil
@@ -249,7 +249,7 @@ func (c *WalletClient) SweepDust(req SweepDust) (TransferSplit, error) {
 // SweepAll will sweep all dust matching the given parameters
 func (c *WalletClient) SweepAll(req SweepAllDust) (TransferSplit, error) {
 	var response TransferSplit
-	if err := c.Wallet("sweep_all", nil, &response); err != nil {
+	if err := c.Wallet("sweep_all", req, &response); err != nil {
 		return response, err
 	}
 	return response, nil
@@ -258,7 +258,7 @@ func (c *WalletClient) SweepAll(req SweepAllDust) (TransferSplit, error) {
 // SweepSingle will sweep all dust matching the given parameters
 func (c *WalletClient) SweepSingle(req SweepSingle) (Transfer, error) {
 	var response Transfer
-	if err := c.Wallet("sweep_single", nil, &response); err != nil {
+	if err := c.Wallet("sweep_single", req, &response); err != nil {
 		return response, err
 	}
 	return response, nil
@@ -519,7 +519,7 @@ func (c *WalletClient) GetTransactionProof(txid string, address string, message
 // CheckTransactionProof will return the result of check a given transaction proof
 func (c *WalletClient) CheckTransactionProof(req CheckTransactionProof) (CheckedProof, error) {
 	var response CheckedProof
-	err := c.Wallet("get_tx_proof", req, &response)
+	err := c.Wallet("check_tx_proof", req, &response)
 
 	if err != nil {
 		return response, err
@@ -556,7 +556,7 @@ func (c *WalletClient) CheckSpendProof(transactionID string, message string, sig
 		TxID      string `json:"txid"`
 		Signature string `json:"signature"`
 		Message   string `json:"message"`
-	}{transactionID, message, signature}
+	}{transactionID, signature, message}
 
 	err := c.Wallet("check_spend_proof", request, &response)
 
@@ -579,7 +579,7 @@ func (c *WalletClient) GetReserveProof(accountIndex uint32, amount uint64, messa
 		Message      string `json:"message"`
 	}{all, accountIndex, amount, message}
 
-	err := c.Wallet("check_spend_proof", request, &response)
+	err := c.Wallet("get_reserve_proof", request, &response)
 
 	if err != nil {
 		return response.Signature, err
@@ -595,9 +595,9 @@ func (c *WalletClient) CheckReserveProof(address string, message string, signatu
 		Address   string `json:"address"`
 		Signature string `json:"signature"`
 		Message   string `json:"message"`
-	}{address, message, signature}
+	}{address, signature, message}
 
-	err := c.Wallet("check_spend_proof", request, &response)
+	err := c.Wallet("check_reserve_proof", request, &response)
 
 	if err != nil {
 		return response, err
@@ -653,10 +653,8 @@ func (c *WalletClient) GetTransfersWithMempool(accountIndex uint32, minHeight ui
 
 	// workaround for bug in 0.14.0.2
 	// it will return confirmations == height for mempool transactions
-	for _, item := range response.Pool {
-		if item.Confirmations > 0 {
-			item.Confirmations = 0
-		}
+	for i := range response.Pool {
+		response.Pool[i].Confirmations = 0
 	}
 
 	return append(response.In, response.Pool...), nil
@@ -1017,10 +1015,13 @@ func (c *WalletClient) ExportMultisigInfo() (string, error) {
 
 // ImportMultisigInfo ...
 func (c *WalletClient) ImportMultisigInfo(info []string) (uint64, error) {
+	req := struct {
+		Info []string `json:"info"`
+	}{info}
 	var rep struct {
 		NumberOutputs uint64 `json:"n_outputs"`
 	}
-	if err := c.Wallet("import_multisig_info", nil, &rep); err != nil {
+	if err := c.Wallet("import_multisig_info", req, &rep); err != nil {
 		return rep.NumberOutputs, err
 	}
 	return rep.NumberOutputs, nil
@@ -75,7 +75,7 @@ type TransferInput struct {
 	SubAddressIndices []uint32      `json:"subaddr_indices,omitempty"`
 	Priority          *uint32       `json:"priority"`
 	Mixin             *uint64       `json:"mixin,omitempty"`
-	RingSize          uint64        `json:"ring_size"`
+	RingSize          uint64        `json:"ring_size,omitempty"` // 0 leaves it to the wallet, see DaemonClient.RingSize
 	UnlockTime        *uint64       `json:"unlock_time,omitempty"`
 	PaymentID         *string       `json:"payment_id,omitempty"`
 	GetTxKey          *bool         `json:"get_tx_key,omitempty"`
@@ -109,10 +109,10 @@ type SweepAllDust struct {
 	AccountIndex      uint32   `json:"account_index"`
 	SubAddressIndices []uint32 `json:"subaddr_indices,omitempty"`
 	Priority          uint32   `json:"priority"`
-	Mixin             uint64   `json:"mixin"`
-	RingSize          uint64   `json:"ring_size"`
+	Mixin             *uint64  `json:"mixin,omitempty"`
+	RingSize          uint64   `json:"ring_size,omitempty"`
 	UnlockTime        uint64   `json:"unlock_time"`
-	PaymentID         string   `json:"payment_id"`
+	PaymentID         *string  `json:"payment_id,omitempty"`
 	GetTxKey          bool     `json:"get_tx_key"`
 	BelowAmount       uint64   `json:"below_amount"`
 	DoNotRelay        bool     `json:"do_not_relay"`
@@ -122,18 +122,18 @@ type SweepAllDust struct {
 
 // SweepSingle represents details about parameters in a sweep_single request
 type SweepSingle struct {
-	Address    string `json:"address"`
-	Priority   uint32 `json:"priority"`
-	Mixin      uint64 `json:"mixin"`
-	RingSize   uint64 `json:"ring_size"`
-	Outputs    uint64 `json:"outputs"`
-	UnlockTime uint64 `json:"unlock_time"`
-	PaymentID  string `json:"payment_id"`
-	GetTxKey   bool   `json:"get_tx_key"`
-	KeyImage   string `json:"key_image"`
-	DoNotRelay bool   `json:"do_not_relay"`
-	GetTxHex   bool   `json:"get_tx_hex"`
-	TxMetadata bool   `json:"tx_metadata"`
+	Address    string  `json:"address"`
+	Priority   uint32  `json:"priority"`
+	Mixin      *uint64 `json:"mixin,omitempty"`
+	RingSize   uint64  `json:"ring_size,omitempty"`
+	Outputs    uint64  `json:"outputs"`
+	UnlockTime uint64  `json:"unlock_time"`
+	PaymentID  *string `json:"payment_id,omitempty"`
+	GetTxKey   bool    `json:"get_tx_key"`
+	KeyImage   string  `json:"key_image"`
+	DoNotRelay bool    `json:"do_not_relay"`
+	GetTxHex   bool    `json:"get_tx_hex"`
+	TxMetadata bool    `json:"tx_metadata"`
 }
 
 // RelayTransaction represents details about parameters in a relay_tx request
@@ -143,12 +143,12 @@ type RelayedTransaction struct {
 
 // Payment contains information about a payment
 type Payment struct {
-	PaymentID       string `json:"payment_id"`
-	TxHash          string `json:"tx_hash"`
-	Amount          uint64 `json:"amount"`
-	BlockHeight     uint64 `json:"block_height"`
-	UnlockTime      uint64 `json:"unlock_time"`
-	SubAddressIndex uint32 `json:"subaddr_index"`
+	PaymentID       string          `json:"payment_id"`
+	TxHash          string          `json:"tx_hash"`
+	Amount          uint64          `json:"amount"`
+	BlockHeight     uint64          `json:"block_height"`
+	UnlockTime      uint64          `json:"unlock_time"`
+	SubAddressIndex SubAddressIndex `json:"subaddr_index"`
 }
 
 // Payments represents a response from a get_payments request
@@ -208,7 +208,7 @@ type TransferEntry struct {
 	Amount                          uint64          `json:"amount"`
 	Fee                             uint64          `json:"Fee"`
 	Note                            string          `json:"note"`
-	Destinations                    []Destination   `json:"destinations"`
+	Destinations                    []Destination   `json:"destinations,omitempty"`
 	Type                            string          `json:"type"`
 	UnlockTime                      uint64          `json:"unlock_time"`
 	SubAddressIndex                 SubAddressIndex `json:"subaddr_index"`
@@ -287,7 +287,7 @@ type SignedMultisigTransaction struct {
 	HashList []string `json:"tx_hash_list"`
 }
 
-/// GetTransferByTxIDResponse ...
+// GetTransferByTxIDResponse ...
 type GetTransferByTxIDResponse struct {
 	Transfer  TransferEntry   `json:"transfer"`
 	Transfers []TransferEntry `json:"transfers"`
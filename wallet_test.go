@@ -0,0 +1,713 @@
+package monero
+
+import "testing"
+
+const (
+	testAddress = "55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt"
+	testTxID    = "c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a"
+
+	// testSubaddress is subaddress 1 of account 0 of the testAddress wallet.
+	testSubaddress = "7BnERTpvL5MbCLtj5n9No7J5oE5hHiB3tVCK5cjSvCsYWD2WRJLFuWeKTLiXo5QJqt2ZwUaLy2Vh1Ad51K7FNgqcHgjW85o"
+)
+
+func testTransferInput() TransferInput {
+	priority := uint32(0)
+	getTxKey := true
+	return TransferInput{
+		Destinations:      []Destination{{Amount: 100000000000, Address: testAddress}},
+		SubAddressIndices: []uint32{0},
+		Priority:          &priority,
+		RingSize:          uint64(Protocol(16).RingSize()),
+		GetTxKey:          &getTxKey,
+	}
+}
+
+func TestWalletConformance(t *testing.T) {
+	srv := newFixtureServer(t, "wallet", true)
+	defer srv.Close()
+	c := NewWalletClient(srv.URL+"/json_rpc", "user", "pass")
+
+	yes := true
+	account := uint32(0)
+	runConformance(t, srv, c.CallClient, []conformanceCase{
+		{
+			name:   "GetBalances",
+			method: "getbalance",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetBalances() },
+			unmodelled: []string{
+				"blocks_to_unlock",
+				"per_subaddress[].blocks_to_unlock",
+				"per_subaddress[].time_to_unlock",
+				"time_to_unlock",
+			},
+		},
+		{
+			name:   "GetBalanceForAccount",
+			method: "getbalance",
+			params: `{"account_index":0}`,
+			call:   func() (interface{}, error) { return c.GetBalanceForAccount(0) },
+			unmodelled: []string{
+				"blocks_to_unlock",
+				"per_subaddress[].blocks_to_unlock",
+				"per_subaddress[].time_to_unlock",
+				"time_to_unlock",
+			},
+		},
+		{
+			name:   "GetAddresses",
+			method: "getaddress",
+			params: `{"account_index":0,"address_index":[0,1]}`,
+			call: func() (interface{}, error) {
+				return c.GetAddresses(&AddressFilters{AccountIndex: 0, AddressIndex: []uint32{0, 1}})
+			},
+			unmodelled: []string{"address"},
+		},
+		{
+			name:       "GetAddressesByAccount",
+			method:     "getaddress",
+			params:     `{"account_index":0}`,
+			call:       func() (interface{}, error) { return c.GetAddressesByAccount(0) },
+			unmodelled: []string{"address"},
+		},
+		{
+			name:   "GetAddressIndex",
+			method: "get_address_index",
+			params: `{"address":"7BnERTpvL5MbCLtj5n9No7J5oE5hHiB3tVCK5cjSvCsYWD2WRJLFuWeKTLiXo5QJqt2ZwUaLy2Vh1Ad51K7FNgqcHgjW85o"}`,
+			call:   func() (interface{}, error) { return c.GetAddressIndex(testSubaddress) },
+		},
+		{
+			name:       "CreateAddress",
+			method:     "create_address",
+			params:     `{"account_index":0,"label":"new-sub"}`,
+			call:       func() (interface{}, error) { return c.CreateAddress(0, "new-sub") },
+			unmodelled: []string{"address_indices", "addresses"},
+		},
+		{
+			name:   "LabelAddress",
+			method: "label_address",
+			params: `{"index":1,"label":"myLabel"}`,
+			call:   func() (interface{}, error) { return nil, c.LabelAddress(1, "myLabel") },
+		},
+		{
+			name:   "GetAccounts",
+			method: "get_accounts",
+			params: `{"tag":"myTag"}`,
+			call:   func() (interface{}, error) { return c.GetAccounts("myTag") },
+		},
+		{
+			name:   "CreateAccount",
+			method: "create_account",
+			params: `{"label":"Secondary account"}`,
+			call:   func() (interface{}, error) { return c.CreateAccount("Secondary account") },
+		},
+		{
+			name:   "LabelAccount",
+			method: "label_account",
+			params: `{"account_index":0,"label":"Primary account"}`,
+			call:   func() (interface{}, error) { return nil, c.LabelAccount(0, "Primary account") },
+		},
+		{
+			name:   "GetAccountTags",
+			method: "get_account_tags",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetAccountTags() },
+		},
+		{
+			name:   "TagAccounts",
+			method: "tag_accounts",
+			params: `{"tag":"myTag","accounts":[0,1]}`,
+			call:   func() (interface{}, error) { return nil, c.TagAccounts("myTag", []uint32{0, 1}) },
+		},
+		{
+			name:   "UntagAccounts",
+			method: "untag_accounts",
+			params: `{"accounts":[1]}`,
+			call:   func() (interface{}, error) { return nil, c.UntagAccounts([]uint32{1}) },
+		},
+		{
+			name:   "SetAccountTagDescription",
+			method: "set_account_tag_description",
+			params: `{"tag":"myTag","description":"Test tag"}`,
+			call:   func() (interface{}, error) { return nil, c.SetAccountTagDescription("myTag", "Test tag") },
+		},
+		{
+			name:   "GetHeight",
+			method: "getheight",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.GetHeight() },
+		},
+		{
+			name:   "Transfer",
+			method: "transfer",
+			params: `{"destinations":[{"amount":100000000000,"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt"}],"account_index":0,"subaddr_indices":[0],"priority":0,"ring_size":16,"get_tx_key":true}`,
+			call:   func() (interface{}, error) { return c.Transfer(testTransferInput()) },
+			unmodelled: []string{
+				"amounts_by_dest",
+				"spent_key_images",
+				"weight",
+			},
+		},
+		{
+			name:       "TransferSplit",
+			method:     "transfer_split",
+			params:     `{"destinations":[{"amount":100000000000,"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt"}],"account_index":0,"subaddr_indices":[0],"priority":0,"ring_size":16,"get_tx_key":true}`,
+			call:       func() (interface{}, error) { return c.TransferSplit(testTransferInput()) },
+			unmodelled: []string{"spent_key_images_list", "weight_list"},
+		},
+		{
+			name:   "SignTransfer",
+			method: "sign_transfer",
+			params: `{"unsigned_txset":"4d6f6e65726f20756e7369676e6564","export_raw":true}`,
+			call: func() (interface{}, error) {
+				return c.SignTransfer(SignTransfer{UnsignedTxSet: "4d6f6e65726f20756e7369676e6564", ExportRaw: true})
+			},
+			unmodelled: []string{"tx_key_list"},
+		},
+		{
+			name:   "SubmitTransfer",
+			method: "submit_transfer",
+			params: `{"tx_data_hex":["4d6f6e65726f207369676e6564"]}`,
+			call: func() (interface{}, error) {
+				return c.SubmitTransfer(SubmitTransfer{TxDataHex: []string{"4d6f6e65726f207369676e6564"}})
+			},
+		},
+		{
+			name:       "SweepDust",
+			method:     "sweep_dust",
+			params:     `{"get_tx_keys":true,"do_not_relay":false,"get_tx_hex":true,"get_tx_metadata":false}`,
+			call:       func() (interface{}, error) { return c.SweepDust(SweepDust{GetTxKeys: true, GetTxHex: true}) },
+			unmodelled: []string{"spent_key_images_list", "weight_list"},
+		},
+		{
+			name:   "SweepAll",
+			method: "sweep_all",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","account_index":0,"subaddr_indices":[0],"priority":1,"ring_size":16,"unlock_time":0,"get_tx_key":true,"below_amount":1000000,"do_not_relay":false,"get_tx_hex":false,"tx_metadata":false}`,
+			call: func() (interface{}, error) {
+				return c.SweepAll(SweepAllDust{Address: testAddress, SubAddressIndices: []uint32{0}, Priority: 1, RingSize: 16, GetTxKey: true, BelowAmount: 1000000})
+			},
+			unmodelled: []string{"spent_key_images_list", "weight_list"},
+		},
+		{
+			name:   "SweepSingle",
+			method: "sweep_single",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","priority":1,"ring_size":16,"outputs":1,"unlock_time":0,"get_tx_key":true,"key_image":"05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd","do_not_relay":false,"get_tx_hex":false,"tx_metadata":false}`,
+			call: func() (interface{}, error) {
+				return c.SweepSingle(SweepSingle{Address: testAddress, Priority: 1, RingSize: 16, Outputs: 1, GetTxKey: true, KeyImage: "05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd"})
+			},
+			unmodelled: []string{
+				"amounts_by_dest",
+				"spent_key_images",
+				"weight",
+			},
+		},
+		{
+			name:   "SweepSingleDefaultRingSize",
+			method: "sweep_single",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","priority":1,"outputs":1,"unlock_time":0,"get_tx_key":true,"key_image":"05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd","do_not_relay":false,"get_tx_hex":false,"tx_metadata":false}`,
+			call: func() (interface{}, error) {
+				return c.SweepSingle(SweepSingle{Address: testAddress, Priority: 1, Outputs: 1, GetTxKey: true, KeyImage: "05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd"})
+			},
+			unmodelled: []string{
+				"amounts_by_dest",
+				"spent_key_images",
+				"weight",
+			},
+		},
+		{
+			name:   "RelayTx",
+			method: "relay_tx",
+			params: `{"hex":"0100000000000000"}`,
+			call:   func() (interface{}, error) { return c.RelayTx("0100000000000000") },
+		},
+		{
+			name:   "Store",
+			method: "store",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.Store() },
+		},
+		{
+			name:       "GetPayments",
+			method:     "get_payments",
+			params:     `{"payment_id":"60900e5603bf96e3"}`,
+			call:       func() (interface{}, error) { return c.GetPayments("60900e5603bf96e3") },
+			unmodelled: []string{"payments[].address", "payments[].locked"},
+		},
+		{
+			name:       "GetBulkPayments",
+			method:     "get_bulk_payments",
+			params:     `{"payment_ids":["60900e5603bf96e3"],"min_block_height":1530000}`,
+			call:       func() (interface{}, error) { return c.GetBulkPayments([]string{"60900e5603bf96e3"}, 1530000) },
+			unmodelled: []string{"payments[].address", "payments[].locked"},
+		},
+		{
+			name:   "IncomingTransfers",
+			method: "incoming_transfers",
+			params: `{"transfer_type":"available","account_index":0,"subaddr_indices":[0]}`,
+			call: func() (interface{}, error) {
+				return c.IncomingTransfers(IncomingTransfers{TransferType: "available", SubAddressIndices: []uint32{0}})
+			},
+			unmodelled: []string{
+				"transfers[].block_height",
+				"transfers[].frozen",
+				"transfers[].pubkey",
+				"transfers[].unlocked",
+			},
+		},
+		{
+			name:   "QueryKey",
+			method: "query_key",
+			params: `{"key_type":"view_key"}`,
+			call:   func() (interface{}, error) { return c.QueryKey("view_key") },
+		},
+		{
+			name:   "MakeIntegratedAddress",
+			method: "make_integrated_address",
+			params: `{"standard_address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","payment_id":"420fa29b2d9a49f5"}`,
+			call: func() (interface{}, error) {
+				return c.MakeIntegratedAddress(MakeIntegratedAddress{StandardAddress: testAddress, PaymentID: "420fa29b2d9a49f5"})
+			},
+		},
+		{
+			name:   "SplitIntegratedAddress",
+			method: "split_integrated_address",
+			params: `{"integrated_address":"5F38Rw9HKeaLQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZXCkbHUXdPHyiUeRyokn"}`,
+			call: func() (interface{}, error) {
+				return c.SplitIntegratedAddress("5F38Rw9HKeaLQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZXCkbHUXdPHyiUeRyokn")
+			},
+		},
+		{
+			name:   "StopWallet",
+			method: "stop_wallet",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.StopWallet() },
+		},
+		{
+			name:   "RescanBlockchain",
+			method: "rescan_blockchain",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.RescanBlockchain() },
+		},
+		{
+			name:   "SetTransactionNotes",
+			method: "set_transaction_notes",
+			params: `{"txids":["c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a"],"notes":["This is an example"]}`,
+			call: func() (interface{}, error) {
+				return nil, c.SetTransactionNotes([]string{testTxID}, []string{"This is an example"})
+			},
+		},
+		{
+			name:   "GetTransactionNotes",
+			method: "get_transaction_notes",
+			params: `{"txids":["c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a"]}`,
+			call:   func() (interface{}, error) { return c.GetTransactionNotes([]string{testTxID}) },
+		},
+		{
+			name:   "SetAttribute",
+			method: "set_attribute",
+			params: `{"key":"my_attribute","value":"my_value"}`,
+			call:   func() (interface{}, error) { return nil, c.SetAttribute("my_attribute", "my_value") },
+		},
+		{
+			name:   "GetAttribute",
+			method: "get_attribute",
+			params: `{"key":"my_attribute"}`,
+			call:   func() (interface{}, error) { return c.GetAttribute("my_attribute") },
+		},
+		{
+			name:   "GetTransactionKey",
+			method: "get_tx_key",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a"}`,
+			call:   func() (interface{}, error) { return c.GetTransactionKey(testTxID) },
+		},
+		{
+			name:   "CheckTransactionKey",
+			method: "check_tx_key",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a","tx_key":"feba662cf8fb6d0d0da18fc9b70ab28e01cc76311278fdd7fe7ab16360762b06","address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt"}`,
+			call: func() (interface{}, error) {
+				return c.CheckTransactionKey(testTxID, "feba662cf8fb6d0d0da18fc9b70ab28e01cc76311278fdd7fe7ab16360762b06", testAddress)
+			},
+		},
+		{
+			name:   "GetTransactionProof",
+			method: "get_tx_proof",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a","address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","message":"this is my proof"}`,
+			call:   func() (interface{}, error) { return c.GetTransactionProof(testTxID, testAddress, "this is my proof") },
+		},
+		{
+			name:   "CheckTransactionProof",
+			method: "check_tx_proof",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a","address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","message":"this is my proof","signature":"InProofV1"}`,
+			call: func() (interface{}, error) {
+				return c.CheckTransactionProof(CheckTransactionProof{TransactionID: testTxID, Address: testAddress, Message: "this is my proof", Signature: "InProofV1"})
+			},
+		},
+		{
+			name:   "GetSpendProof",
+			method: "get_spend_proof",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a","message":"this is my proof"}`,
+			call:   func() (interface{}, error) { return c.GetSpendProof(testTxID, "this is my proof") },
+		},
+		{
+			name:   "CheckSpendProof",
+			method: "check_spend_proof",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a","signature":"SpendProofV1","message":"this is my proof"}`,
+			call:   func() (interface{}, error) { return c.CheckSpendProof(testTxID, "this is my proof", "SpendProofV1") },
+		},
+		{
+			name:   "GetReserveProof",
+			method: "get_reserve_proof",
+			params: `{"all":false,"account_index":0,"amount":100000000000,"message":"this is my proof"}`,
+			call:   func() (interface{}, error) { return c.GetReserveProof(0, 100000000000, "this is my proof", false) },
+		},
+		{
+			name:   "CheckReserveProof",
+			method: "check_reserve_proof",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","signature":"ReserveProofV1","message":"this is my proof"}`,
+			call: func() (interface{}, error) {
+				return c.CheckReserveProof(testAddress, "this is my proof", "ReserveProofV1")
+			},
+		},
+		{
+			name:   "GetTransfers",
+			method: "get_transfers",
+			params: `{"in":true,"pool":true,"account_index":0}`,
+			call: func() (interface{}, error) {
+				return c.GetTransfers(GetTransfersFilter{In: &yes, Pool: &yes, AccountIndex: &account})
+			},
+			unmodelled: []string{
+				"failed[].amounts",
+				"failed[].locked",
+				"failed[].subaddr_indices",
+				"in[].amounts",
+				"in[].locked",
+				"in[].subaddr_indices",
+				"out[].amounts",
+				"out[].locked",
+				"out[].subaddr_indices",
+				"pending[].amounts",
+				"pending[].locked",
+				"pending[].subaddr_indices",
+				"pool[].amounts",
+				"pool[].locked",
+				"pool[].subaddr_indices",
+			},
+		},
+		{
+			name:   "GetPoolTransfers",
+			method: "get_transfers",
+			params: `{"min_height":1560000,"account_index":0,"pool":true,"filter_by_height":true}`,
+			call:   func() (interface{}, error) { return c.GetPoolTransfers(1560000, 0) },
+			unmodelled: []string{
+				"failed[].amounts",
+				"failed[].locked",
+				"failed[].subaddr_indices",
+				"in[].amounts",
+				"in[].locked",
+				"in[].subaddr_indices",
+				"out[].amounts",
+				"out[].locked",
+				"out[].subaddr_indices",
+				"pending[].amounts",
+				"pending[].locked",
+				"pending[].subaddr_indices",
+				"pool[].amounts",
+				"pool[].locked",
+				"pool[].subaddr_indices",
+			},
+		},
+		{
+			name:   "GetTransfersWithMempool",
+			method: "get_transfers",
+			params: `{"min_height":1560000,"account_index":0,"in":true,"pool":true,"filter_by_height":true}`,
+			call:   func() (interface{}, error) { return c.GetTransfersWithMempool(0, 1560000) },
+			unmodelled: []string{
+				"failed[].amounts",
+				"failed[].locked",
+				"failed[].subaddr_indices",
+				"in[].amounts",
+				"in[].locked",
+				"in[].subaddr_indices",
+				"out[].amounts",
+				"out[].locked",
+				"out[].subaddr_indices",
+				"pending[].amounts",
+				"pending[].locked",
+				"pending[].subaddr_indices",
+				"pool[].amounts",
+				"pool[].locked",
+				"pool[].subaddr_indices",
+			},
+		},
+		{
+			name:   "GetIncomingTransfers",
+			method: "get_transfers",
+			params: `{"min_height":1560000,"account_index":0,"in":true,"filter_by_height":true}`,
+			call:   func() (interface{}, error) { return c.GetIncomingTransfers(0, 1560000) },
+			unmodelled: []string{
+				"failed[].amounts",
+				"failed[].locked",
+				"failed[].subaddr_indices",
+				"in[].amounts",
+				"in[].locked",
+				"in[].subaddr_indices",
+				"out[].amounts",
+				"out[].locked",
+				"out[].subaddr_indices",
+				"pending[].amounts",
+				"pending[].locked",
+				"pending[].subaddr_indices",
+				"pool[].amounts",
+				"pool[].locked",
+				"pool[].subaddr_indices",
+			},
+		},
+		{
+			name:   "GetOutgoingTransfers",
+			method: "get_transfers",
+			params: `{"min_height":1560000,"max_height":1570000,"account_index":0,"out":true,"filter_by_height":true}`,
+			call:   func() (interface{}, error) { return c.GetOutgoingTransfers(0, 1560000, 1570000) },
+			unmodelled: []string{
+				"failed[].amounts",
+				"failed[].locked",
+				"failed[].subaddr_indices",
+				"in[].amounts",
+				"in[].locked",
+				"in[].subaddr_indices",
+				"out[].amounts",
+				"out[].locked",
+				"out[].subaddr_indices",
+				"pending[].amounts",
+				"pending[].locked",
+				"pending[].subaddr_indices",
+				"pool[].amounts",
+				"pool[].locked",
+				"pool[].subaddr_indices",
+			},
+		},
+		{
+			name:   "GetTransferByTxID",
+			method: "get_transfer_by_txid",
+			params: `{"txid":"c36258a276018c3a4bc1f195a7fb530f50cd63a4fa765fb7c6f7f49fc051762a"}`,
+			call:   func() (interface{}, error) { return c.GetTransferByTxID(testTxID) },
+			unmodelled: []string{
+				"transfer.amounts",
+				"transfer.locked",
+				"transfer.subaddr_indices",
+				"transfers[].amounts",
+				"transfers[].locked",
+				"transfers[].subaddr_indices",
+			},
+		},
+		{
+			name:   "Sign",
+			method: "sign",
+			params: `{"data":"This is sample data to be signed"}`,
+			call:   func() (interface{}, error) { return c.Sign("This is sample data to be signed") },
+		},
+		{
+			name:   "Verify",
+			method: "verify",
+			params: `{"data":"This is sample data to be signed","address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","signature":"SigV2DnQYF11xZ1ahLJxddohCroiEJRnUe1tgwD5ksmFMzQ9NcRdbxLPrEdQW3e8w4sLpqhSup5tU9igQqeAR8j7r7Sty"}`,
+			call: func() (interface{}, error) {
+				return c.Verify("This is sample data to be signed", testAddress, "SigV2DnQYF11xZ1ahLJxddohCroiEJRnUe1tgwD5ksmFMzQ9NcRdbxLPrEdQW3e8w4sLpqhSup5tU9igQqeAR8j7r7Sty")
+			},
+			unmodelled: []string{
+				"old",
+				"signature_type",
+				"version",
+			},
+		},
+		{
+			name:   "ExportOutputs",
+			method: "export_outputs",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.ExportOutputs() },
+		},
+		{
+			name:   "ImportOutputs",
+			method: "import_outputs",
+			params: `{"outputs_data_hex":"4d6f6e65726f206f7574707574206578706f727404"}`,
+			call:   func() (interface{}, error) { return c.ImportOutputs("4d6f6e65726f206f7574707574206578706f727404") },
+		},
+		{
+			name:       "ExportKeyImages",
+			method:     "export_key_images",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.ExportKeyImages() },
+			unmodelled: []string{"offset"},
+		},
+		{
+			name:   "ImportKeyImages",
+			method: "import_key_images",
+			params: `{"signed_key_images":[{"key_image":"05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd","signature":"0fd4fe39"}]}`,
+			call: func() (interface{}, error) {
+				return c.ImportKeyImages([]SignedKeyImage{{KeyImage: "05e6d25f7f6e32e0bbb12c2f8e6d98f3cc6d3a37d6fb4ab27fabb2c0c94ba7bd", Signature: "0fd4fe39"}})
+			},
+			unmodelled: []string{"signed_key_images"},
+		},
+		{
+			name:   "MakeURI",
+			method: "make_uri",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","payment_id":"420fa29b2d9a49f5","amount":10,"tx_description":"Testing out the make_uri function.","recipient_name":"el00ruobuob Stagenet wallet"}`,
+			call: func() (interface{}, error) {
+				return c.MakeURI(URISpec{Address: testAddress, PaymentID: "420fa29b2d9a49f5", Amount: 10, Description: "Testing out the make_uri function.", RecipientName: "el00ruobuob Stagenet wallet"})
+			},
+		},
+		{
+			name:   "ParseURI",
+			method: "parse_uri",
+			params: `{"uri":"monero:55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt?tx_amount=0.000000000010"}`,
+			call:   func() (interface{}, error) { return c.ParseURI("monero:" + testAddress + "?tx_amount=0.000000000010") },
+		},
+		{
+			name:   "GetAddressBook",
+			method: "get_address_book",
+			params: `{"entries":[0]}`,
+			call:   func() (interface{}, error) { return c.GetAddressBook([]uint{0}) },
+		},
+		{
+			name:   "AddAddressBookEntry",
+			method: "add_address_book",
+			params: `{"address":"55LTR8KniP4LQGJSPtbYDacR7dz8RBFnsfAKMaMuwUNYX6aQbBcovzDPyrQF9KXF9tVU6Xk3K8no1BywnJX6GvZX8yJsXvt","description":"Second account"}`,
+			call:   func() (interface{}, error) { return c.AddAddressBookEntry(testAddress, "", "Second account") },
+		},
+		{
+			name:   "GetAddressBookEntries",
+			method: "get_address_book",
+			params: `{"entries":[0]}`,
+			call:   func() (interface{}, error) { return c.GetAddressBookEntries([]uint64{0}) },
+		},
+		{
+			name:   "DeleteAddressBookEntry",
+			method: "delete_address_book",
+			params: `{"index":1}`,
+			call:   func() (interface{}, error) { return nil, c.DeleteAddressBookEntry(1) },
+		},
+		{
+			name:   "RescanSpent",
+			method: "rescan_spent",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.RescanSpent() },
+		},
+		{
+			name:   "Refresh",
+			method: "refresh",
+			params: `{"start_height":100000}`,
+			call:   func() (interface{}, error) { return c.Refresh(100000) },
+		},
+		{
+			name:   "StartMining",
+			method: "start_mining",
+			params: `{"threads_count":1,"do_background_mining":true,"ignore_battery":false}`,
+			call: func() (interface{}, error) {
+				return nil, c.StartMining(StartMining{ThreadsCount: 1, BackgroundMining: true})
+			},
+		},
+		{
+			name:   "StopMining",
+			method: "stop_mining",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.StopMining() },
+		},
+		{
+			name:       "GetLanguages",
+			method:     "get_languages",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetLanguages() },
+			unmodelled: []string{"languages_local"},
+		},
+		{
+			name:   "CreateWallet",
+			method: "create_wallet",
+			params: `{"filename":"mytestwallet","password":"mytestpassword","language":"English"}`,
+			call:   func() (interface{}, error) { return nil, c.CreateWallet("mytestwallet", "mytestpassword", "English") },
+		},
+		{
+			name:   "OpenWallet",
+			method: "open_wallet",
+			params: `{"filename":"mytestwallet","password":"mytestpassword"}`,
+			call:   func() (interface{}, error) { return nil, c.OpenWallet("mytestwallet", "mytestpassword") },
+		},
+		{
+			name:   "CloseWallet",
+			method: "close_wallet",
+			params: `null`,
+			call:   func() (interface{}, error) { return nil, c.CloseWallet() },
+		},
+		{
+			name:   "ChangeWalletPassword",
+			method: "change_wallet_password",
+			params: `{"old_password":"theCurrentSecretPassPhrase","new_password":"theNewSecretPassPhrase"}`,
+			call: func() (interface{}, error) {
+				return nil, c.ChangeWalletPassword("theCurrentSecretPassPhrase", "theNewSecretPassPhrase")
+			},
+		},
+		{
+			name:   "IsMultisig",
+			method: "is_multisig",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.IsMultisig() },
+		},
+		{
+			name:       "PrepareMultisig",
+			method:     "prepare_multisig",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.PrepareMultisig() },
+			unmodelled: []string{"address"},
+		},
+		{
+			name:   "MakeMultisig",
+			method: "make_multisig",
+			params: `{"multisig_info":["MultisigV1K4tGGe8QirZdHgTYoBZMumSug97fdDyM3Z63M3ZY5VXvAdoZvx16HJzPCGaNs6r4FZXdfhKUhRkzRPS5ZjB4A1a8gSvBkzFixkhXtyDCCbVnTkTtQSKCzgjbgC8VGWDxiPLAx6GQ4r1XVskzvZyZpWqZfDfdxSAx1tQoR4CuDRmNp3Te"],"threshold":2,"password":""}`,
+			call: func() (interface{}, error) {
+				return c.MakeMultisig([]string{"MultisigV1K4tGGe8QirZdHgTYoBZMumSug97fdDyM3Z63M3ZY5VXvAdoZvx16HJzPCGaNs6r4FZXdfhKUhRkzRPS5ZjB4A1a8gSvBkzFixkhXtyDCCbVnTkTtQSKCzgjbgC8VGWDxiPLAx6GQ4r1XVskzvZyZpWqZfDfdxSAx1tQoR4CuDRmNp3Te"}, 2, "")
+			},
+		},
+		{
+			name:   "ExportMultisigInfo",
+			method: "export_multisig_info",
+			params: `null`,
+			call:   func() (interface{}, error) { return c.ExportMultisigInfo() },
+		},
+		{
+			name:   "ImportMultisigInfo",
+			method: "import_multisig_info",
+			params: `{"info":["4d6f6e65726f206d756c7469736967206578706f7274"]}`,
+			call: func() (interface{}, error) {
+				return c.ImportMultisigInfo([]string{"4d6f6e65726f206d756c7469736967206578706f7274"})
+			},
+		},
+		{
+			name:   "FinalizeMultisig",
+			method: "finalize_multisig",
+			params: `{"password":"","multisig_info":["MultisigxV1"]}`,
+			call:   func() (interface{}, error) { return c.FinalizeMultisig("", []string{"MultisigxV1"}) },
+		},
+		{
+			name:   "ExchangeMultisigKeys",
+			method: "exchange_multisig_keys",
+			params: `{"password":"","multisig_info":["MultisigxV1"]}`,
+			call:   func() (interface{}, error) { return c.ExchangeMultisigKeys("", []string{"MultisigxV1"}) },
+		},
+		{
+			name:   "SignMultisig",
+			method: "sign_multisig",
+			params: `{"tx_data_hex":"4d6f6e65726f206d756c7469736967"}`,
+			call:   func() (interface{}, error) { return c.SignMultisig("4d6f6e65726f206d756c7469736967") },
+		},
+		{
+			name:   "SubmitMultisig",
+			method: "submit_multisig",
+			params: `{"tx_data_hex":"4d6f6e65726f206d756c7469736967207369676e6564"}`,
+			call:   func() (interface{}, error) { return c.SubmitMultisig("4d6f6e65726f206d756c7469736967207369676e6564") },
+		},
+		{
+			name:       "GetVersion",
+			method:     "get_version",
+			params:     `null`,
+			call:       func() (interface{}, error) { return c.GetVersion() },
+			unmodelled: []string{"release"},
+		},
+	})
+}